@@ -0,0 +1,163 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	collectionsAccountHolderURL   = "/collection/v1_0/accountholder"
+	disbursementsAccountHolderURL = "/disbursement/v1_0/accountholder"
+	remittancesAccountHolderURL   = "/remittance/v1_0/accountholder"
+)
+
+// BasicUserInfo holds the personal information of an account holder
+type BasicUserInfo struct {
+	GivenName  string `json:"given_name"`
+	FamilyName string `json:"family_name"`
+	MiddleName string `json:"middle_name,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Birthdate  string `json:"birthdate,omitempty"`
+	Locale     string `json:"locale,omitempty"`
+	Gender     string `json:"gender,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+// AccountStatus is the result of validating an account holder
+type AccountStatus int
+
+// Account statuses returned by ValidateAccountHolder
+const (
+	// AccountStatusUnknown means the account holder could not be validated, e.g. because the request failed
+	AccountStatusUnknown AccountStatus = iota
+	// AccountStatusActive means the account holder is registered and active
+	AccountStatusActive
+	// AccountStatusInactive means the account holder is not registered or not active
+	AccountStatusInactive
+)
+
+func (s AccountStatus) String() string {
+	switch s {
+	case AccountStatusActive:
+		return "active"
+	case AccountStatusInactive:
+		return "inactive"
+	}
+	return "unknown"
+}
+
+// accountHolderURL returns the URL of an account holder endpoint below baseURL,
+// e.g. /collection/v1_0/accountholder/msisdn/46733123450/basicuserinfo
+func accountHolderURL(baseURL string, party Party, endpoint string) string {
+	return fmt.Sprintf("%s/%s/%s", baseURL, party.path(), endpoint)
+}
+
+// getBasicUserInfo retrieves the personal information of an account holder of the product at baseURL.
+// Momo only offers basic user info for MSISDN parties.
+func (c *Client) getBasicUserInfo(ctx context.Context, baseURL string, party Party) (*BasicUserInfo, error) {
+	ctx, cancel := c.withTimeout(ctx, OperationGetBasicUserInfo)
+	defer cancel()
+
+	if err := party.Validate(); err != nil {
+		return nil, err
+	}
+	if party.PartyIDType != PartyIDTypeMSISDN {
+		return nil, fmt.Errorf("basic user info is only available for %s parties, got %s", PartyIDTypeMSISDN, party.PartyIDType)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, accountHolderURL(baseURL, party, "basicuserinfo"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	info := &BasicUserInfo{}
+	err = json.Unmarshal(res.Body, info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// validateAccountHolder checks whether an account holder of the product at baseURL is registered and active.
+// An account holder that Momo does not know of is reported as AccountStatusInactive rather than as an error.
+func (c *Client) validateAccountHolder(ctx context.Context, baseURL string, party Party) (AccountStatus, error) {
+	ctx, cancel := c.withTimeout(ctx, OperationIsPayeeActive)
+	defer cancel()
+
+	if err := party.Validate(); err != nil {
+		return AccountStatusUnknown, err
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, accountHolderURL(baseURL, party, "active"), nil)
+	if err != nil {
+		return AccountStatusUnknown, err
+	}
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return AccountStatusUnknown, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		return AccountStatusInactive, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return AccountStatusUnknown, newErrorResponse(res)
+	}
+
+	result := struct {
+		Result bool `json:"result"`
+	}{}
+	err = json.Unmarshal(res.Body, &result)
+	if err != nil {
+		return AccountStatusUnknown, err
+	}
+	if !result.Result {
+		return AccountStatusInactive, nil
+	}
+	return AccountStatusActive, nil
+}
+
+// ValidateAccountHolder checks whether an account holder is registered and active, e.g. before requesting a payment
+func (c *CollectionServiceOp) ValidateAccountHolder(ctx context.Context, party Party) (AccountStatus, error) {
+	return c.client.validateAccountHolder(ctx, collectionsAccountHolderURL, party)
+}
+
+// ValidateAccountHolder checks whether an account holder is registered and active, e.g. before a transfer
+func (c *DisbursementServiceOp) ValidateAccountHolder(ctx context.Context, party Party) (AccountStatus, error) {
+	return c.client.validateAccountHolder(ctx, disbursementsAccountHolderURL, party)
+}
+
+// ValidateAccountHolder checks whether an account holder is registered and active, e.g. before a transfer
+func (c *RemittanceServiceOp) ValidateAccountHolder(ctx context.Context, party Party) (AccountStatus, error) {
+	return c.client.validateAccountHolder(ctx, remittancesAccountHolderURL, party)
+}
+
+// GetBasicUserInfo retrieves the name and other personal information of an account holder,
+// e.g. to confirm who a payer is before requesting a payment
+func (c *CollectionServiceOp) GetBasicUserInfo(ctx context.Context, party Party) (*BasicUserInfo, error) {
+	return c.client.getBasicUserInfo(ctx, collectionsAccountHolderURL, party)
+}
+
+// GetBasicUserInfo retrieves the name and other personal information of an account holder,
+// e.g. to confirm who a payee is before a transfer
+func (c *DisbursementServiceOp) GetBasicUserInfo(ctx context.Context, party Party) (*BasicUserInfo, error) {
+	return c.client.getBasicUserInfo(ctx, disbursementsAccountHolderURL, party)
+}
+
+// GetBasicUserInfo retrieves the name and other personal information of an account holder,
+// e.g. to confirm who a payee is before a transfer
+func (c *RemittanceServiceOp) GetBasicUserInfo(ctx context.Context, party Party) (*BasicUserInfo, error) {
+	return c.client.getBasicUserInfo(ctx, remittancesAccountHolderURL, party)
+}
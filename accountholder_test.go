@@ -0,0 +1,119 @@
+package gomomo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetBasicUserInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		get     func(party Party) (*BasicUserInfo, error)
+	}{
+		{"Collection", collectionsAccountHolderURL, func(party Party) (*BasicUserInfo, error) {
+			return client.Collection.GetBasicUserInfo(ctx, party)
+		}},
+		{"Disbursement", disbursementsAccountHolderURL, func(party Party) (*BasicUserInfo, error) {
+			return client.Disbursement.GetBasicUserInfo(ctx, party)
+		}},
+		{"Remittance", remittancesAccountHolderURL, func(party Party) (*BasicUserInfo, error) {
+			return client.Remittance.GetBasicUserInfo(ctx, party)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc(tt.baseURL+"/msisdn/46733123450/basicuserinfo", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodGet)
+				fmt.Fprint(w, `{"given_name": "Sand", "family_name": "Box", "birthdate": "1976-08-13", "locale": "sv_SE", "gender": "MALE", "status": "ACTIVE"}`)
+			})
+			mux.HandleFunc(tt.baseURL+"/msisdn/46733123459/basicuserinfo", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"code":"RESOURCE_NOT_FOUND","message":"Requested resource was not found."}`)
+			})
+
+			info, err := tt.get(Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "46733123450"})
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			expected := &BasicUserInfo{GivenName: "Sand", FamilyName: "Box", Birthdate: "1976-08-13", Locale: "sv_SE", Gender: "MALE", Status: "ACTIVE"}
+			if !reflect.DeepEqual(info, expected) {
+				t.Errorf("GetBasicUserInfo\n got=%#v\nwant=%#v", info, expected)
+			}
+
+			_, err = tt.get(Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "46733123459"})
+			if !IsNotFound(err) {
+				t.Errorf("Expected a not found error but got %v", err)
+			}
+
+			_, err = tt.get(Party{PartyIDType: PartyIDTypeMSISDN})
+			if err == nil {
+				t.Errorf("Expected a non nil error for a party without an ID")
+			}
+
+			_, err = tt.get(Party{PartyIDType: PartyIDTypeEmail, PartyID: "sandbox@example.com"})
+			if err == nil {
+				t.Errorf("Expected a non nil error for a non MSISDN party")
+			}
+		})
+	}
+}
+
+func TestValidateAccountHolder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(collectionsAccountHolderURL+"/msisdn/46733123450/active", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"result": true}`)
+	})
+	mux.HandleFunc(collectionsAccountHolderURL+"/email/sand@example.com/active", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result": false}`)
+	})
+	mux.HandleFunc(collectionsAccountHolderURL+"/email/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != collectionsAccountHolderURL+"/email/a#b@example.com/active" {
+			t.Errorf("Expected the party ID to be escaped but got path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"result": false}`)
+	})
+	mux.HandleFunc(collectionsAccountHolderURL+"/party_code/ab12/active", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"RESOURCE_NOT_FOUND","message":"Requested resource was not found."}`)
+	})
+	mux.HandleFunc(collectionsAccountHolderURL+"/msisdn/46733123459/active", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code":"INVALID_PARTY_ID","message":"Invalid party ID"}`)
+	})
+
+	tests := []struct {
+		name     string
+		party    Party
+		expected AccountStatus
+		wantErr  bool
+	}{
+		{"active MSISDN", Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "46733123450"}, AccountStatusActive, false},
+		{"inactive email", Party{PartyIDType: PartyIDTypeEmail, PartyID: "sand@example.com"}, AccountStatusInactive, false},
+		{"email with reserved characters", Party{PartyIDType: PartyIDTypeEmail, PartyID: "a#b@example.com"}, AccountStatusInactive, false},
+		{"unknown party code", Party{PartyIDType: PartyIDTypePartyCode, PartyID: "ab12"}, AccountStatusInactive, false},
+		{"failed request", Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "46733123459"}, AccountStatusUnknown, true},
+		{"invalid party", Party{PartyIDType: PartyIDTypeMSISDN}, AccountStatusUnknown, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := client.Collection.ValidateAccountHolder(ctx, tt.party)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAccountHolder error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if status != tt.expected {
+				t.Errorf("ValidateAccountHolder = %s, want %s", status, tt.expected)
+			}
+		})
+	}
+}
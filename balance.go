@@ -0,0 +1,133 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BalanceErrors is returned by GetBalances when the balance of some currencies could not be retrieved.
+// It maps each of those currencies to its error.
+type BalanceErrors map[string]error
+
+func (e BalanceErrors) Error() string {
+	currencies := make([]string, 0, len(e))
+	for currency := range e {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	messages := make([]string, len(currencies))
+	for i, currency := range currencies {
+		messages[i] = fmt.Sprintf("%s: %v", currency, e[currency])
+	}
+	return "balance lookup failed for " + strings.Join(messages, "; ")
+}
+
+// getBalance retrieves the balance at urlStr
+func (c *Client) getBalance(ctx context.Context, urlStr string) (*BalanceResponse, error) {
+	ctx, cancel := c.withTimeout(ctx, OperationGetBalance)
+	defer cancel()
+
+	req, err := c.NewRequest(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	balance := &BalanceResponse{}
+	err = json.Unmarshal(res.Body, balance)
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// getBalanceInCurrency retrieves the balance of the wallet in currency of the account whose balance is at balanceURL.
+// Currency rules are not applied, as coercing the currency of a lookup would return the balance of another wallet.
+func (c *Client) getBalanceInCurrency(ctx context.Context, balanceURL, currency string) (*BalanceResponse, error) {
+	if err := ValidateCurrency(currency); err != nil {
+		return nil, err
+	}
+	return c.getBalance(ctx, fmt.Sprintf("%s/%s", balanceURL, currency))
+}
+
+// getBalances retrieves the balance of every currency concurrently with get. Balances that could not be
+// retrieved are left out of the result and reported in a BalanceErrors.
+func getBalances(ctx context.Context, currencies []string, get func(ctx context.Context, currency string) (*BalanceResponse, error)) (map[string]*BalanceResponse, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		balances = make(map[string]*BalanceResponse, len(currencies))
+		errs     = BalanceErrors{}
+		seen     = make(map[string]bool, len(currencies))
+	)
+	for _, currency := range currencies {
+		if seen[currency] {
+			continue
+		}
+		seen[currency] = true
+
+		wg.Add(1)
+		go func(currency string) {
+			defer wg.Done()
+			balance, err := get(ctx, currency)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[currency] = err
+				return
+			}
+			balances[currency] = balance
+		}(currency)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return balances, errs
+	}
+	return balances, nil
+}
+
+// GetBalanceInCurrency returns the balance of the account's wallet in currency
+func (c *CollectionServiceOp) GetBalanceInCurrency(ctx context.Context, currency string) (*BalanceResponse, error) {
+	return c.client.getBalanceInCurrency(ctx, collectionsBalanceURL, currency)
+}
+
+// GetBalances returns the balance of the account's wallet in each of currencies, retrieved concurrently
+func (c *CollectionServiceOp) GetBalances(ctx context.Context, currencies []string) (map[string]*BalanceResponse, error) {
+	return getBalances(ctx, currencies, c.GetBalanceInCurrency)
+}
+
+// GetBalanceInCurrency returns the balance of the account's wallet in currency
+func (c *DisbursementServiceOp) GetBalanceInCurrency(ctx context.Context, currency string) (*BalanceResponse, error) {
+	return c.client.getBalanceInCurrency(ctx, disbursementsBalanceURL, currency)
+}
+
+// GetBalances returns the balance of the account's wallet in each of currencies, retrieved concurrently
+func (c *DisbursementServiceOp) GetBalances(ctx context.Context, currencies []string) (map[string]*BalanceResponse, error) {
+	return getBalances(ctx, currencies, c.GetBalanceInCurrency)
+}
+
+// GetBalanceInCurrency returns the balance of the account's wallet in currency
+func (c *RemittanceServiceOp) GetBalanceInCurrency(ctx context.Context, currency string) (*BalanceResponse, error) {
+	return c.client.getBalanceInCurrency(ctx, remittancesBalanceURL, currency)
+}
+
+// GetBalances returns the balance of the account's wallet in each of currencies, retrieved concurrently
+func (c *RemittanceServiceOp) GetBalances(ctx context.Context, currencies []string) (map[string]*BalanceResponse, error) {
+	return getBalances(ctx, currencies, c.GetBalanceInCurrency)
+}
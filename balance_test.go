@@ -0,0 +1,114 @@
+package gomomo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetBalanceInCurrency(t *testing.T) {
+	tests := []struct {
+		name       string
+		balanceURL string
+		get        func(currency string) (*BalanceResponse, error)
+	}{
+		{"Collection", collectionsBalanceURL, func(currency string) (*BalanceResponse, error) {
+			return client.Collection.GetBalanceInCurrency(ctx, currency)
+		}},
+		{"Disbursement", disbursementsBalanceURL, func(currency string) (*BalanceResponse, error) {
+			return client.Disbursement.GetBalanceInCurrency(ctx, currency)
+		}},
+		{"Remittance", remittancesBalanceURL, func(currency string) (*BalanceResponse, error) {
+			return client.Remittance.GetBalanceInCurrency(ctx, currency)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc(tt.balanceURL+"/UGX", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodGet)
+				fmt.Fprint(w, `{"availableBalance": "1500", "currency": "UGX"}`)
+			})
+
+			balance, err := tt.get("UGX")
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			expected := &BalanceResponse{AvailableBalance: MustParseAmount("1500"), Currency: "UGX"}
+			if !reflect.DeepEqual(balance, expected) {
+				t.Errorf("GetBalanceInCurrency\n got=%#v\nwant=%#v", balance, expected)
+			}
+
+			if _, err := tt.get("XYZ"); err == nil {
+				t.Errorf("Expected a non nil error for an unknown currency")
+			}
+		})
+	}
+}
+
+func TestCollectionServiceOp_GetBalances(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, currency := range []string{"EUR", "UGX"} {
+		currency := currency
+		mux.HandleFunc(collectionsBalanceURL+"/"+currency, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"availableBalance": "100", "currency": %q}`, currency)
+		})
+	}
+	mux.HandleFunc(collectionsBalanceURL+"/USD", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"RESOURCE_NOT_FOUND","message":"Requested resource was not found."}`)
+	})
+
+	t.Run("GetBalances returns every balance", func(t *testing.T) {
+		balances, err := client.Collection.GetBalances(ctx, []string{"EUR", "UGX", "EUR"})
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if len(balances) != 2 || balances["EUR"].Currency != "EUR" || balances["UGX"].Currency != "UGX" {
+			t.Errorf("Unexpected balances %#v", balances)
+		}
+	})
+
+	t.Run("GetBalances reports the currencies that failed", func(t *testing.T) {
+		balances, err := client.Collection.GetBalances(ctx, []string{"EUR", "USD"})
+		errs, ok := err.(BalanceErrors)
+		if !ok {
+			t.Fatalf("Expected BalanceErrors but got %v", err)
+		}
+		if len(errs) != 1 || !IsNotFound(errs["USD"]) {
+			t.Errorf("Unexpected errors %v", errs)
+		}
+		if len(balances) != 1 || balances["EUR"] == nil {
+			t.Errorf("Unexpected balances %#v", balances)
+		}
+	})
+}
+
+func TestGetBalances_IgnoresCurrencyRules(t *testing.T) {
+	setup()
+	defer teardown()
+	client.EnableSandboxCurrencyCoercion()
+
+	for _, currency := range []string{"EUR", "UGX"} {
+		currency := currency
+		mux.HandleFunc(collectionsBalanceURL+"/"+currency, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"availableBalance": "100", "currency": %q}`, currency)
+		})
+	}
+
+	balances, err := client.Collection.GetBalances(ctx, []string{"UGX", "EUR"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	for _, currency := range []string{"EUR", "UGX"} {
+		if balances[currency] == nil || balances[currency].Currency != currency {
+			t.Errorf("Expected the %s balance but got %#v", currency, balances[currency])
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// maxCallbackBodySize is the largest callback body read by CallbackHandler
+const maxCallbackBodySize = 1 << 20
+
+// CallbackFunc is called with the reference ID and final status of a completed transaction.
+// Returning an error makes the CallbackHandler answer 500 so that Momo retries the callback.
+type CallbackFunc func(ctx context.Context, referenceID string, status *PaymentStatusResponse) error
+
+// CallbackHandler is an http.Handler which receives the callbacks Momo sends to the provider callback
+// host when a transaction completes. The product is taken from the callback URL, so callback URLs
+// should contain the product name, e.g. https://example.com/momo/collection. The reference ID is read
+// from the X-Reference-Id header or, failing that, the referenceId query parameter; callbacks carrying
+// neither are rejected with 400 Bad Request.
+type CallbackHandler struct {
+	// AllowedHosts lists the IP addresses and CIDR ranges callbacks are accepted from.
+	// Callbacks are refused with 403 Forbidden while it is empty, unless AllowAnyHost is set.
+	AllowedHosts []string
+	// AllowAnyHost accepts callbacks from any address, e.g. behind a proxy which already checks their source
+	AllowAnyHost bool
+
+	// OnRequestToPayCompleted is called for Collection request to pay callbacks
+	OnRequestToPayCompleted CallbackFunc
+	// OnTransferCompleted is called for Disbursement transfer callbacks
+	OnTransferCompleted CallbackFunc
+	// OnRemittanceCompleted is called for Remittance transfer callbacks
+	OnRemittanceCompleted CallbackFunc
+}
+
+var _ http.Handler = &CallbackHandler{}
+
+// ServeHTTP handles a single Momo callback
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.allowed(r.RemoteAddr) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	status := &PaymentStatusResponse{}
+	err := json.NewDecoder(io.LimitReader(r.Body, maxCallbackBodySize)).Decode(status)
+	if err != nil || status.Status == "" {
+		http.Error(w, "malformed callback body", http.StatusBadRequest)
+		return
+	}
+
+	referenceID := r.Header.Get("X-Reference-Id")
+	if referenceID == "" {
+		referenceID = r.URL.Query().Get("referenceId")
+	}
+	if referenceID == "" {
+		http.Error(w, "missing reference ID", http.StatusBadRequest)
+		return
+	}
+
+	fn := h.callbackFor(callbackProduct(r.URL.Path, status))
+	if fn == nil {
+		http.Error(w, "no handler for callback", http.StatusNotFound)
+		return
+	}
+	if err := fn(r.Context(), referenceID, status); err != nil {
+		http.Error(w, "callback failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *CallbackHandler) callbackFor(p Product) CallbackFunc {
+	switch p {
+	case ProductCollection:
+		return h.OnRequestToPayCompleted
+	case ProductDisbursement:
+		return h.OnTransferCompleted
+	case ProductRemittance:
+		return h.OnRemittanceCompleted
+	}
+	return nil
+}
+
+// allowed reports whether a callback from remoteAddr is accepted
+func (h *CallbackHandler) allowed(remoteAddr string) bool {
+	if h.AllowAnyHost {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range h.AllowedHosts {
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if allowedIP := net.ParseIP(allowed); allowedIP != nil && allowedIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// callbackProduct returns the product named in the callback URL path. Request to pay callbacks
+// without a product in their path are recognised by their payer.
+func callbackProduct(path string, status *PaymentStatusResponse) Product {
+	for _, segment := range strings.Split(path, "/") {
+		switch p := Product(strings.ToLower(segment)); p {
+		case ProductCollection, ProductDisbursement, ProductRemittance:
+			return p
+		}
+	}
+	if status.Payer.PartyID != "" {
+		return ProductCollection
+	}
+	return ""
+}
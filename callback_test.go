@@ -0,0 +1,115 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCallbackHandler_ServeHTTP(t *testing.T) {
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	body := `{"financialTransactionId": "23503452", "externalId": "947354", "amount": "100", "currency": "UGX", "payer": {"partyIdType": "MSISDN", "partyId": "256784123456"}, "payeeNote": "", "payerMessage": "", "status": "SUCCESSFUL"}`
+
+	var gotProduct Product
+	var gotReferenceID string
+	var gotStatus *PaymentStatusResponse
+	record := func(p Product) CallbackFunc {
+		return func(ctx context.Context, referenceID string, status *PaymentStatusResponse) error {
+			gotProduct, gotReferenceID, gotStatus = p, referenceID, status
+			return nil
+		}
+	}
+	handler := &CallbackHandler{
+		AllowedHosts:            []string{"192.0.2.0/24", "198.51.100.7"},
+		OnRequestToPayCompleted: record(ProductCollection),
+		OnTransferCompleted:     record(ProductDisbursement),
+		OnRemittanceCompleted: func(ctx context.Context, referenceID string, status *PaymentStatusResponse) error {
+			return errors.New("database unavailable")
+		},
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		target        string
+		remoteAddr    string
+		body          string
+		noReferenceID bool
+		code          int
+		product       Product
+	}{
+		{name: "collection callback", method: http.MethodPut, target: "/momo/collection", remoteAddr: "192.0.2.10:4000", body: body, code: http.StatusOK, product: ProductCollection},
+		{name: "disbursement callback", method: http.MethodPost, target: "/momo/disbursement", remoteAddr: "198.51.100.7:4000", body: body, code: http.StatusOK, product: ProductDisbursement},
+		{name: "callback without product in path", method: http.MethodPut, target: "/momo", remoteAddr: "192.0.2.10:4000", body: body, code: http.StatusOK, product: ProductCollection},
+		{name: "reference ID in query", method: http.MethodPut, target: "/momo/collection?referenceId=" + referenceID, remoteAddr: "192.0.2.10:4000", body: body, noReferenceID: true, code: http.StatusOK, product: ProductCollection},
+		{name: "missing reference ID", method: http.MethodPut, target: "/momo/collection", remoteAddr: "192.0.2.10:4000", body: body, noReferenceID: true, code: http.StatusBadRequest},
+		{name: "handler error", method: http.MethodPut, target: "/momo/remittance", remoteAddr: "192.0.2.10:4000", body: body, code: http.StatusInternalServerError},
+		{name: "unknown source", method: http.MethodPut, target: "/momo/collection", remoteAddr: "203.0.113.1:4000", body: body, code: http.StatusForbidden},
+		{name: "malformed body", method: http.MethodPut, target: "/momo/collection", remoteAddr: "192.0.2.10:4000", body: `{"status":`, code: http.StatusBadRequest},
+		{name: "missing status", method: http.MethodPut, target: "/momo/collection", remoteAddr: "192.0.2.10:4000", body: `{"amount": "100"}`, code: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodGet, target: "/momo/collection", remoteAddr: "192.0.2.10:4000", code: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		gotProduct, gotReferenceID, gotStatus = "", "", nil
+		r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		r.RemoteAddr = tt.remoteAddr
+		if !tt.noReferenceID {
+			r.Header.Set("X-Reference-Id", referenceID)
+		}
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tt.code {
+			t.Errorf("%s: expected status %d but got %d", tt.name, tt.code, w.Code)
+		}
+		if tt.product == "" {
+			if gotProduct != "" {
+				t.Errorf("%s: expected no callback to be called but got %q", tt.name, gotProduct)
+			}
+			continue
+		}
+		if gotProduct != tt.product {
+			t.Errorf("%s: expected the %s callback to be called but got %q", tt.name, tt.product, gotProduct)
+		}
+		if gotReferenceID != referenceID {
+			t.Errorf("%s: expected reference ID %s but got %s", tt.name, referenceID, gotReferenceID)
+		}
+		if gotStatus == nil || gotStatus.Status != "SUCCESSFUL" || gotStatus.Amount.String() != "100" {
+			t.Errorf("%s: expected the parsed status but got %#v", tt.name, gotStatus)
+		}
+	}
+}
+
+func TestCallbackHandler_AllowedHosts(t *testing.T) {
+	body := `{"amount": "100", "currency": "UGX", "status": "SUCCESSFUL"}`
+	ok := func(ctx context.Context, referenceID string, status *PaymentStatusResponse) error {
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		handler *CallbackHandler
+		code    int
+	}{
+		{"no allowed hosts", &CallbackHandler{OnRequestToPayCompleted: ok}, http.StatusForbidden},
+		{"any host allowed", &CallbackHandler{AllowAnyHost: true, OnRequestToPayCompleted: ok}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPut, "/momo/collection", strings.NewReader(body))
+		r.RemoteAddr = "203.0.113.1:4000"
+		r.Header.Set("X-Reference-Id", "6c6eb16c-8b34-4d5d-bd41-2a9303f65075")
+		w := httptest.NewRecorder()
+
+		tt.handler.ServeHTTP(w, r)
+
+		if w.Code != tt.code {
+			t.Errorf("%s: expected status %d but got %d", tt.name, tt.code, w.Code)
+		}
+	}
+}
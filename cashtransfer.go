@@ -0,0 +1,221 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const remittancesCashTransferURL = "/remittance/v2_0/cashtransfer"
+
+// IdentificationType is the type of identity document of the originator of a cash transfer
+type IdentificationType string
+
+// Identification types accepted by Momo
+const (
+	IdentificationTypePassport       IdentificationType = "PASS"
+	IdentificationTypeNationalID     IdentificationType = "NRIN"
+	IdentificationTypeIdentityCard   IdentificationType = "IDCD"
+	IdentificationTypeDrivingLicence IdentificationType = "DRLC"
+	IdentificationTypeOther          IdentificationType = "OTHR"
+)
+
+// Originator is the person sending a cash transfer. Its details are required for cross-border compliance.
+type Originator struct {
+	FirstName string
+	LastName  string
+	// Country is the ISO 3166-1 alpha-2 code of the country the transfer originates from, e.g. "SE"
+	Country              string
+	IdentificationType   IdentificationType
+	IdentificationNumber string
+	DateOfBirth          time.Time
+	// MSISDN, Email, LanguageCode and Gender are optional
+	MSISDN       string
+	Email        string
+	LanguageCode string
+	Gender       string
+}
+
+// Validate checks that the originator has a name, a country, a supported identity document and a date of birth in the past
+func (o *Originator) Validate() error {
+	if o.FirstName == "" || o.LastName == "" {
+		return errors.New("first and last name are required")
+	}
+	if !isCountryCode(o.Country) {
+		return fmt.Errorf("invalid country %q, expected an ISO 3166-1 alpha-2 code", o.Country)
+	}
+	switch o.IdentificationType {
+	case IdentificationTypePassport, IdentificationTypeNationalID, IdentificationTypeIdentityCard,
+		IdentificationTypeDrivingLicence, IdentificationTypeOther:
+	default:
+		return fmt.Errorf("invalid identification type %q", o.IdentificationType)
+	}
+	if o.IdentificationNumber == "" {
+		return errors.New("identification number is required")
+	}
+	if o.DateOfBirth.IsZero() || o.DateOfBirth.After(time.Now()) {
+		return errors.New("date of birth must be in the past")
+	}
+	return nil
+}
+
+func isCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CashTransferRequest holds the details of a cross-border cash transfer to a payee
+type CashTransferRequest struct {
+	Amount     Amount
+	Currency   string
+	Payee      Party
+	ExternalID string
+	Originator Originator
+	// OriginalAmount and OriginalCurrency are the amount sent by the originator before conversion
+	OriginalAmount   Amount
+	OriginalCurrency string
+	PayerMessage     string
+	PayeeNote        string
+
+	// CallbackURL is where Momo sends the final status of the transfer, sent as X-Callback-Url
+	CallbackURL string
+	// ReferenceID, when set, is used as the X-Reference-Id of the request. See WithReferenceID.
+	ReferenceID string
+}
+
+// Validate checks that the request has a payee, a valid originator and positive amounts in known currencies
+func (r *CashTransferRequest) Validate() error {
+	if err := r.Payee.Validate(); err != nil {
+		return fmt.Errorf("payee: %v", err)
+	}
+	if err := r.Originator.Validate(); err != nil {
+		return fmt.Errorf("originator: %v", err)
+	}
+	if err := validateAmount(r.Amount, r.Currency); err != nil {
+		return err
+	}
+	if err := validateAmount(r.OriginalAmount, r.OriginalCurrency); err != nil {
+		return fmt.Errorf("original amount: %v", err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the request with the originator as the payer fields expected by Momo
+func (r CashTransferRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount                    Amount             `json:"amount"`
+		Currency                  string             `json:"currency"`
+		Payee                     Party              `json:"payee"`
+		ExternalID                string             `json:"externalId"`
+		OriginatingCountry        string             `json:"orginatingCountry"`
+		OriginalAmount            Amount             `json:"originalAmount"`
+		OriginalCurrency          string             `json:"originalCurrency"`
+		PayerMessage              string             `json:"payerMessage"`
+		PayeeNote                 string             `json:"payeeNote"`
+		PayerIdentificationType   IdentificationType `json:"payerIdentificationType"`
+		PayerIdentificationNumber string             `json:"payerIdentificationNumber"`
+		PayerFirstName            string             `json:"payerFirstName"`
+		PayerSurName              string             `json:"payerSurName"`
+		PayerDateOfBirth          string             `json:"payerDateOfBirth"`
+		PayerLanguageCode         string             `json:"payerLanguageCode,omitempty"`
+		PayerEmail                string             `json:"payerEmail,omitempty"`
+		PayerMSISDN               string             `json:"payerMsisdn,omitempty"`
+		PayerGender               string             `json:"payerGender,omitempty"`
+	}{
+		Amount:                    r.Amount,
+		Currency:                  r.Currency,
+		Payee:                     r.Payee,
+		ExternalID:                r.ExternalID,
+		OriginatingCountry:        r.Originator.Country,
+		OriginalAmount:            r.OriginalAmount,
+		OriginalCurrency:          r.OriginalCurrency,
+		PayerMessage:              r.PayerMessage,
+		PayeeNote:                 r.PayeeNote,
+		PayerIdentificationType:   r.Originator.IdentificationType,
+		PayerIdentificationNumber: r.Originator.IdentificationNumber,
+		PayerFirstName:            r.Originator.FirstName,
+		PayerSurName:              r.Originator.LastName,
+		PayerDateOfBirth:          r.Originator.DateOfBirth.Format("2006-01-02"),
+		PayerLanguageCode:         r.Originator.LanguageCode,
+		PayerEmail:                r.Originator.Email,
+		PayerMSISDN:               r.Originator.MSISDN,
+		PayerGender:               r.Originator.Gender,
+	})
+}
+
+// CashTransferStatusResponse holds the status of a cash transfer created with CashTransfer
+type CashTransferStatusResponse struct {
+	FinancialTransactionID string            `json:"financialTransactionId,omitempty"`
+	ExternalID             string            `json:"externalId"`
+	Amount                 Amount            `json:"amount"`
+	Currency               string            `json:"currency"`
+	Payee                  Party             `json:"payee"`
+	OriginatingCountry     string            `json:"orginatingCountry,omitempty"`
+	OriginalAmount         Amount            `json:"originalAmount"`
+	OriginalCurrency       string            `json:"originalCurrency,omitempty"`
+	PayerMessage           string            `json:"payerMessage,omitempty"`
+	PayeeNote              string            `json:"payeeNote,omitempty"`
+	Status                 TransactionStatus `json:"status"`
+	Reason                 *ErrorReason      `json:"reason,omitempty"`
+}
+
+// CashTransfer sends a cross-border cash transfer to a payee and returns its reference ID.
+// A transfer whose reference ID already exists is reported as a success.
+func (c *RemittanceServiceOp) CashTransfer(ctx context.Context, r *CashTransferRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationCashTransfer)
+	defer cancel()
+
+	err := r.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	requestBody := *r
+	requestBody.Currency, err = c.client.applyCurrencyRule(r.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	return c.client.create(ctx, remittancesCashTransferURL, requestBody, r.ReferenceID, r.CallbackURL, func(ctx context.Context, referenceID string) error {
+		_, err := c.GetCashTransferStatus(ctx, referenceID)
+		return err
+	})
+}
+
+// GetCashTransferStatus retrieves the status of a cash transfer using the reference ID returned by CashTransfer
+func (c *RemittanceServiceOp) GetCashTransferStatus(ctx context.Context, referenceID string) (*CashTransferStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetCashTransferStatus)
+	defer cancel()
+
+	urlStr := fmt.Sprintf("%s/%s", remittancesCashTransferURL, referenceID)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	status := &CashTransferStatusResponse{}
+	err = json.Unmarshal(res.Body, status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
@@ -0,0 +1,126 @@
+package gomomo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newCashTransferRequest() *CashTransferRequest {
+	return &CashTransferRequest{
+		Amount:     NewAmount(100),
+		Currency:   "EUR",
+		Payee:      Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+		ExternalID: "34232",
+		Originator: Originator{
+			FirstName:            "Sand",
+			LastName:             "Box",
+			Country:              "SE",
+			IdentificationType:   IdentificationTypePassport,
+			IdentificationNumber: "AB123456",
+			DateOfBirth:          time.Date(1976, 8, 13, 0, 0, 0, 0, time.UTC),
+		},
+		OriginalAmount:   NewAmount(1100),
+		OriginalCurrency: "SEK",
+		PayerMessage:     "school fees",
+		PayeeNote:        "term 2",
+	}
+}
+
+func TestRemittanceServiceOp_CashTransfer(t *testing.T) {
+	t.Run("CashTransfer returns 202_ACCEPTED", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		mux.HandleFunc(remittancesCashTransferURL, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			expected := map[string]interface{}{
+				"orginatingCountry":         "SE",
+				"originalAmount":            "1100",
+				"originalCurrency":          "SEK",
+				"payerIdentificationType":   "PASS",
+				"payerIdentificationNumber": "AB123456",
+				"payerFirstName":            "Sand",
+				"payerSurName":              "Box",
+				"payerDateOfBirth":          "1976-08-13",
+			}
+			for k, v := range expected {
+				if body[k] != v {
+					t.Errorf("Expected %s to be %v but got %v", k, v, body[k])
+				}
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+
+		referenceID, err := client.Remittance.CashTransfer(ctx, newCashTransferRequest())
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if referenceID == "" {
+			t.Errorf("Expected referenceID to be a non empty string")
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		modify func(r *CashTransferRequest)
+	}{
+		{"missing originator name", func(r *CashTransferRequest) { r.Originator.LastName = "" }},
+		{"invalid originator country", func(r *CashTransferRequest) { r.Originator.Country = "Sweden" }},
+		{"missing identification", func(r *CashTransferRequest) { r.Originator.IdentificationNumber = "" }},
+		{"unknown identification type", func(r *CashTransferRequest) { r.Originator.IdentificationType = "PASSPORT" }},
+		{"date of birth in the future", func(r *CashTransferRequest) { r.Originator.DateOfBirth = time.Now().Add(time.Hour) }},
+		{"missing payee", func(r *CashTransferRequest) { r.Payee = Party{} }},
+		{"missing original currency", func(r *CashTransferRequest) { r.OriginalCurrency = "" }},
+	}
+	for _, tt := range invalid {
+		t.Run("CashTransfer rejects a "+tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			r := newCashTransferRequest()
+			tt.modify(r)
+			if _, err := client.Remittance.CashTransfer(ctx, r); err == nil {
+				t.Errorf("Expected a non nil error")
+			}
+		})
+	}
+}
+
+func TestRemittanceServiceOp_GetCashTransferStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expectedStatus := CashTransferStatusResponse{
+		FinancialTransactionID: "2312",
+		ExternalID:             "34232",
+		Amount:                 NewAmount(100),
+		Currency:               "EUR",
+		Payee:                  Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+		OriginatingCountry:     "SE",
+		OriginalAmount:         NewAmount(1100),
+		OriginalCurrency:       "SEK",
+		Status:                 StatusSuccessful,
+	}
+
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	mux.HandleFunc(fmt.Sprintf("%s/%s", remittancesCashTransferURL, referenceID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		json.NewEncoder(w).Encode(expectedStatus)
+	})
+
+	actualStatus, err := client.Remittance.GetCashTransferStatus(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !reflect.DeepEqual(actualStatus, &expectedStatus) {
+		t.Errorf("GetCashTransferStatus\n got=%#v\nwant=%#v", actualStatus, expectedStatus)
+	}
+}
@@ -43,12 +43,19 @@ func main() {
 }
 
 func createSandboxUserCmd(c *cli.Context) error {
-	client := gomomo.NewClient(c.String("key"), "sandbox", "https://sandbox.momodeveloper.mtn.com/")
-	refID, err := client.Sandbox.CreateSandboxUser(c.String("callback"))
+	client, err := gomomo.NewClient(
+		gomomo.WithSubscriptionKey(c.String("key")),
+		gomomo.WithEnvironment(gomomo.EnvironmentSandbox),
+		gomomo.WithBaseURL(gomomo.SandboxBaseURL),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
-	apiKey, err := client.Sandbox.GenerateSandboxUserAPIKey(refID)
+	refID, err := client.Sandbox.CreateSandboxUser(c.Context, c.String("callback"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	apiKey, err := client.Sandbox.GenerateSandboxUserAPIKey(c.Context, refID)
 	if err != nil {
 		log.Fatal(err)
 	}
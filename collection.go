@@ -5,25 +5,48 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
-	"strconv"
+	"unicode/utf8"
 )
 
 const (
-	collectionsTokenURL           = "/collection/token/"
-	collectionsRequestToPayURL    = "/collection/v1_0/requesttopay"
-	collectionsBalanceURL         = "/collection/v1_0/account/balance"
-	collectionsIsAccountActiveURL = "/collection/v1_0/accountholder/msisdn/"
+	collectionsTokenURL               = "/collection/token/"
+	collectionsRequestToPayURL        = "/collection/v1_0/requesttopay"
+	collectionsRequestToWithdrawURL   = "/collection/v1_0/requesttowithdraw"
+	collectionsRequestToWithdrawV2URL = "/collection/v2_0/requesttowithdraw"
+	collectionsBalanceURL             = "/collection/v1_0/account/balance"
 )
 
 // CollectionService handles communication with Collection related methods of the
 // Momo API to enable remote collections of bills, fees or taxes
 type CollectionService interface {
 	RequestToPay(ctx context.Context, mobile string, amount int64, id, payeeNote, payerMessage, currency string) (string, error)
+	CreateRequestToPay(ctx context.Context, r *RequestToPayRequest) (string, error)
 	GetTransaction(ctx context.Context, transactionID string) (*PaymentStatusResponse, error)
+	WaitForTransaction(ctx context.Context, transactionID string, opts *WaitOptions) (*PaymentStatusResponse, error)
+	RequestToWithdraw(ctx context.Context, r *RequestToPayRequest) (string, error)
+	RequestToWithdrawV2(ctx context.Context, r *RequestToPayRequest) (string, error)
+	GetWithdrawStatus(ctx context.Context, referenceID string) (*PaymentStatusResponse, error)
+	SendDeliveryNotification(ctx context.Context, referenceID, message, language string) error
+	CreatePreApproval(ctx context.Context, r *PreApprovalRequest) (string, error)
+	GetPreApprovalStatus(ctx context.Context, referenceID string) (*PreApprovalStatusResponse, error)
+	GetApprovedPreApprovals(ctx context.Context, payer Party) ([]PreApproval, error)
+	CancelPreApproval(ctx context.Context, preApprovalID string) error
+	CreateInvoice(ctx context.Context, r *InvoiceRequest) (string, error)
+	GetInvoiceStatus(ctx context.Context, referenceID string) (*InvoiceStatusResponse, error)
+	CancelInvoice(ctx context.Context, referenceID, externalID string) error
+	CreatePayment(ctx context.Context, r *PaymentRequest) (string, error)
+	GetPaymentStatus(ctx context.Context, referenceID string) (*PaymentResult, error)
 	GetBalance(ctx context.Context) (*BalanceResponse, error)
+	GetBalanceInCurrency(ctx context.Context, currency string) (*BalanceResponse, error)
+	GetBalances(ctx context.Context, currencies []string) (map[string]*BalanceResponse, error)
 	IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error)
+	ValidateAccountHolder(ctx context.Context, party Party) (AccountStatus, error)
+	GetBasicUserInfo(ctx context.Context, party Party) (*BasicUserInfo, error)
+	Authorize(ctx context.Context, r *AuthorizeRequest) (*AuthorizeResponse, error)
+	GetUserToken(ctx context.Context, authReqID string) (*UserToken, error)
+	AwaitUserToken(ctx context.Context, auth *AuthorizeResponse, opts *WaitOptions) (*UserToken, error)
+	GetUserInfoWithConsent(ctx context.Context, userToken string) (*UserInfo, error)
 	GetToken(ctx context.Context, apiKey, userID string) (string, error)
 }
 
@@ -35,47 +58,84 @@ type CollectionServiceOp struct {
 
 var _ CollectionService = &CollectionServiceOp{}
 
-// RequestToPay is used to request a payment from a consumer (Payer).
+// RequestToPay is used to request a payment from a consumer (Payer) identified by their mobile number.
+// It is a shorthand for CreateRequestToPay.
 func (c *CollectionServiceOp) RequestToPay(ctx context.Context, mobile string, amount int64, id, payeeNote, payerMessage, currency string) (string, error) {
-	if c.client.Environment == "sandbox" {
-		currency = "EUR"
-	}
-
-	requestBody := paymentRequestBody{
-		Amount:     amount,
+	return c.CreateRequestToPay(ctx, &RequestToPayRequest{
+		Amount:     NewAmount(amount),
 		Currency:   currency,
 		ExternalID: id,
-		Payer: paymentDetails{
-			PartyIDType: "MSISDN",
+		Payer: Party{
+			PartyIDType: PartyIDTypeMSISDN,
 			PartyID:     mobile,
 		},
 		PayerMessage: payerMessage,
 		PayeeNote:    payeeNote,
-	}
+	})
+}
+
+// CreateRequestToPay is used to request a payment from a consumer (Payer). Set ReferenceID on the request
+// or use WithReferenceID on ctx to resend a request safely; a request whose reference ID already exists
+// is reported as a success.
+func (c *CollectionServiceOp) CreateRequestToPay(ctx context.Context, r *RequestToPayRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationRequestToPay)
+	defer cancel()
+
+	return c.requestPayment(ctx, collectionsRequestToPayURL, r, c.GetTransaction)
+}
+
+// GetTransaction retrieves transaction information using the transactionId returned by RequestToPay
+func (c *CollectionServiceOp) GetTransaction(ctx context.Context, transactionID string) (*PaymentStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetTransaction)
+	defer cancel()
+
+	return c.paymentStatus(ctx, fmt.Sprintf("%s/%s", collectionsRequestToPayURL, transactionID))
+}
 
-	req, err := c.client.NewRequest(ctx, http.MethodPost, collectionsRequestToPayURL, requestBody)
-	transactionID := req.Header.Get("X-Reference-Id")
+// RequestToWithdraw is used to request a withdrawal (cash-out) from a consumer (Payer) using the v1_0 API.
+// The payer is asked to authorize the withdrawal, just as for RequestToPay.
+func (c *CollectionServiceOp) RequestToWithdraw(ctx context.Context, r *RequestToPayRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationRequestToWithdraw)
+	defer cancel()
 
+	return c.requestPayment(ctx, collectionsRequestToWithdrawURL, r, c.GetWithdrawStatus)
+}
+
+// RequestToWithdrawV2 is used to request a withdrawal (cash-out) from a consumer (Payer) using the v2_0 API
+func (c *CollectionServiceOp) RequestToWithdrawV2(ctx context.Context, r *RequestToPayRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationRequestToWithdraw)
+	defer cancel()
+
+	return c.requestPayment(ctx, collectionsRequestToWithdrawV2URL, r, c.GetWithdrawStatus)
+}
+
+// GetWithdrawStatus retrieves withdrawal information using the reference ID returned by RequestToWithdraw
+func (c *CollectionServiceOp) GetWithdrawStatus(ctx context.Context, referenceID string) (*PaymentStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetWithdrawStatus)
+	defer cancel()
+
+	return c.paymentStatus(ctx, fmt.Sprintf("%s/%s", collectionsRequestToWithdrawURL, referenceID))
+}
+
+// requestPayment sends r to urlStr and returns its reference ID. A reference ID that already
+// exists is confirmed with lookup.
+func (c *CollectionServiceOp) requestPayment(ctx context.Context, urlStr string, r *RequestToPayRequest, lookup func(ctx context.Context, referenceID string) (*PaymentStatusResponse, error)) (string, error) {
+	err := r.Validate()
 	if err != nil {
 		return "", err
 	}
 
-	res, err := c.client.Do(ctx, req)
+	requestBody := *r
+	requestBody.Currency, err = c.client.applyCurrencyRule(r.Currency)
 	if err != nil {
-		log.Println(string(res.Body))
 		return "", err
 	}
 
-	if res.StatusCode != http.StatusAccepted {
-		return "", fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
-
-	return transactionID, nil
+	return c.client.create(ctx, urlStr, requestBody, r.ReferenceID, r.CallbackURL, paymentLookup(lookup))
 }
 
-// GetTransaction retrieves transaction information using the transactionId returned by RequestToPay
-func (c *CollectionServiceOp) GetTransaction(ctx context.Context, transactionID string) (*PaymentStatusResponse, error) {
-	urlStr := fmt.Sprintf("%s/%s", collectionsRequestToPayURL, transactionID)
+// paymentStatus retrieves the status of a request to pay or withdraw
+func (c *CollectionServiceOp) paymentStatus(ctx context.Context, urlStr string) (*PaymentStatusResponse, error) {
 	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
 	if err != nil {
 		return nil, err
@@ -87,7 +147,7 @@ func (c *CollectionServiceOp) GetTransaction(ctx context.Context, transactionID
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
+		return nil, newErrorResponse(res)
 	}
 
 	status := &PaymentStatusResponse{}
@@ -100,70 +160,59 @@ func (c *CollectionServiceOp) GetTransaction(ctx context.Context, transactionID
 
 // GetBalance returns the balance of the account
 func (c *CollectionServiceOp) GetBalance(ctx context.Context) (*BalanceResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodGet, collectionsBalanceURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return nil, err
-	}
+	return c.client.getBalance(ctx, collectionsBalanceURL)
+}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
+// IsPayeeActive checks if an account holder identified by their mobile number is registered and active in the system.
+// It is a shorthand for ValidateAccountHolder.
+func (c *CollectionServiceOp) IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error) {
+	status, err := c.ValidateAccountHolder(ctx, Party{PartyIDType: PartyIDTypeMSISDN, PartyID: mobileNumber})
+	return status == AccountStatusActive, err
+}
 
-	balance := &BalanceResponse{}
-	err = json.Unmarshal(res.Body, balance)
-	if err != nil {
-		return nil, err
-	}
-	return balance, nil
+// GetToken creates an access token which can then be used to authorize and authenticate towards the other end-points of the Collections API.
+// The credentials are kept on the product's TokenSource, so subsequent requests fetch and refresh tokens automatically.
+func (c *CollectionServiceOp) GetToken(ctx context.Context, apiKey, userID string) (string, error) {
+	ts := c.client.TokenSource(ProductCollection)
+	ts.SetCredentials(userID, apiKey)
+	return ts.Token(ctx)
 }
 
-// IsPayeeActive checks if an account holder is registered and active in the system
-func (c *CollectionServiceOp) IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error) {
-	urlStr := fmt.Sprintf("%s/%s/active", collectionsIsAccountActiveURL, mobileNumber)
-	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
-	if err != nil {
-		return false, err
-	}
+// maxDeliveryNotificationLength is the longest message accepted by SendDeliveryNotification
+const maxDeliveryNotificationLength = 160
 
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return false, err
-	}
+// SendDeliveryNotification sends an SMS to the payer of a request to pay, e.g. to remind them of a pending
+// payment prompt. language is an optional ISO 639 code sent as the Language header.
+func (c *CollectionServiceOp) SendDeliveryNotification(ctx context.Context, referenceID, message, language string) error {
+	ctx, cancel := c.client.withTimeout(ctx, OperationSendDeliveryNotification)
+	defer cancel()
 
-	if res.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
+	if message == "" {
+		return errors.New("notification message is required")
+	}
+	if utf8.RuneCountInString(message) > maxDeliveryNotificationLength {
+		return fmt.Errorf("notification message must not be longer than %d characters", maxDeliveryNotificationLength)
 	}
 
-	return true, nil
-}
-
-// GetToken creates an access token which can then be used to authorize and authenticate towards the other end-points of the Collections API
-func (c *CollectionServiceOp) GetToken(ctx context.Context, apiKey, userID string) (string, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodPost, collectionsTokenURL, nil)
+	urlStr := fmt.Sprintf("%s/%s/deliverynotification", collectionsRequestToPayURL, referenceID)
+	requestBody := struct {
+		NotificationMessage string `json:"notificationMessage"`
+	}{NotificationMessage: message}
+	req, err := c.client.NewRequest(ctx, http.MethodPost, urlStr, requestBody)
 	if err != nil {
-		return "", err
+		return err
+	}
+	if language != "" {
+		req.Header.Set("Language", language)
 	}
-	req.SetBasicAuth(userID, apiKey)
 
 	res, err := c.client.Do(ctx, req)
 	if err != nil {
-		return "", err
-	}
-	if res.StatusCode != http.StatusOK {
-		code := strconv.Itoa(res.StatusCode)
-		return "", errors.New("status Code received is " + code)
+		return err
 	}
-	token := &tokenResponse{}
 
-	err = json.Unmarshal(res.Body, token)
-	if err != nil {
-		return "", err
+	if res.StatusCode != http.StatusOK {
+		return newErrorResponse(res)
 	}
-	c.client.Token = token.AccessToken
-	return token.AccessToken, nil
+	return nil
 }
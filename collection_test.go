@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -26,8 +27,8 @@ func TestCollectionServiceOp_GetToken(t *testing.T) {
 			t.Errorf("Expected 'token' but got %s", token)
 		}
 
-		if client.Token != token {
-			t.Errorf("Expected 'token' to be set on client but got %s", token)
+		if client.Token != "" {
+			t.Errorf("Expected the shared client Token to be left unset but got %s", client.Token)
 		}
 	})
 
@@ -66,16 +67,125 @@ func TestCollectionServiceOp_RequestToPay(t *testing.T) {
 	})
 }
 
+func TestCollectionServiceOp_CreateRequestToPay(t *testing.T) {
+	t.Run("CreateRequestToPay sends the request body and callback URL", func(t *testing.T) {
+		setup()
+		defer teardown()
+		referenceID := NewReferenceID()
+
+		mux.HandleFunc(collectionsRequestToPayURL, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			testHeaders(t, r, headers{
+				"X-Callback-Url": "https://example.com/momo/collection",
+				"X-Reference-Id": referenceID,
+			})
+			body := RequestToPayRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if body.Payer.PartyIDType != PartyIDTypeEmail || body.Payer.PartyID != "payer@example.com" {
+				t.Errorf("Expected an EMAIL payer but got %#v", body.Payer)
+			}
+			if body.PayeeNote != "payee" || body.PayerMessage != "payer" {
+				t.Errorf("Expected payee note and payer message to be sent but got %q and %q", body.PayeeNote, body.PayerMessage)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+		transactionID, err := client.Collection.CreateRequestToPay(ctx, &RequestToPayRequest{
+			Amount:       NewAmount(500),
+			Currency:     "EUR",
+			ExternalID:   "34232",
+			Payer:        Party{PartyIDType: PartyIDTypeEmail, PartyID: "payer@example.com"},
+			PayerMessage: "payer",
+			PayeeNote:    "payee",
+			CallbackURL:  "https://example.com/momo/collection",
+			ReferenceID:  referenceID,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if transactionID != referenceID {
+			t.Errorf("Expected transactionID %s but got %s", referenceID, transactionID)
+		}
+	})
+
+	t.Run("CreateRequestToPay rejects an invalid payer", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		_, err := client.Collection.CreateRequestToPay(ctx, &RequestToPayRequest{
+			Amount:   NewAmount(500),
+			Currency: "EUR",
+			Payer:    Party{PartyIDType: "ALIAS", PartyID: "payer"},
+		})
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+	})
+}
+
+func TestCollectionServiceOp_RequestToPay_ReferenceID(t *testing.T) {
+	referenceID := NewReferenceID()
+
+	t.Run("RequestToPay sends the caller supplied reference ID", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		mux.HandleFunc(collectionsRequestToPayURL, func(w http.ResponseWriter, r *http.Request) {
+			testHeaders(t, r, headers{"X-Reference-Id": referenceID})
+			w.WriteHeader(http.StatusAccepted)
+		})
+		transactionID, err := client.Collection.RequestToPay(WithReferenceID(ctx, referenceID), "25678999720", 500, "34232", "payee", "payer", "UGX")
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if transactionID != referenceID {
+			t.Errorf("Expected transactionID %s but got %s", referenceID, transactionID)
+		}
+	})
+
+	t.Run("RequestToPay treats a 409_CONFLICT for an existing transaction as success", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		mux.HandleFunc(collectionsRequestToPayURL, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"code":"RESOURCE_ALREADY_EXIST","message":"Duplicated reference id. Creation of resource failed."}`)
+		})
+		mux.HandleFunc(collectionsRequestToPayURL+"/"+referenceID, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			fmt.Fprint(w, `{"amount": "500", "currency": "UGX", "externalId": "34232", "status": "PENDING"}`)
+		})
+		transactionID, err := client.Collection.RequestToPay(WithReferenceID(ctx, referenceID), "25678999720", 500, "34232", "payee", "payer", "UGX")
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if transactionID != referenceID {
+			t.Errorf("Expected transactionID %s but got %s", referenceID, transactionID)
+		}
+	})
+
+	t.Run("RequestToPay rejects a reference ID that is not a UUID", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		_, err := client.Collection.RequestToPay(WithReferenceID(ctx, "order-1"), "25678999720", 500, "34232", "payee", "payer", "UGX")
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+	})
+}
+
 func TestCollectionServiceOp_GetTransaction(t *testing.T) {
 	setup()
 	defer teardown()
 
 	expectedStatus := PaymentStatusResponse{
-		Amount:                 "500",
+		Amount:                 MustParseAmount("500"),
 		Currency:               "UGX",
-		FinancialTransactionID: 2312,
+		FinancialTransactionID: "2312",
 		ExternalID:             "3232",
-		Payer: paymentDetails{
+		Payer: Party{
 			PartyIDType: "MSISDN",
 			PartyID:     "4656473839",
 		},
@@ -107,7 +217,7 @@ func TestCollectionServiceOp_GetBalance(t *testing.T) {
 	defer teardown()
 
 	expectedBalance := BalanceResponse{
-		AvailableBalance: "500",
+		AvailableBalance: MustParseAmount("500"),
 		Currency:         "UGX",
 	}
 
@@ -131,15 +241,136 @@ func TestCollectionServiceOp_IsPayeeActive(t *testing.T) {
 	setup()
 	defer teardown()
 	mobileNumber := "256789997290"
-	urlStr := fmt.Sprintf("%s%s/active", collectionsIsAccountActiveURL, mobileNumber)
+	urlStr := fmt.Sprintf("%s/msisdn/%s/active", collectionsAccountHolderURL, mobileNumber)
 
 	mux.HandleFunc(urlStr, func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
 		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"result": true}`)
 	})
 
-	_, err := client.Collection.IsPayeeActive(ctx, mobileNumber)
+	active, err := client.Collection.IsPayeeActive(ctx, mobileNumber)
 	if err != nil {
 		t.Fatalf("unexpected error %s", err)
 	}
+	if !active {
+		t.Errorf("Expected the payee to be active")
+	}
+}
+
+func TestCollectionServiceOp_RequestToWithdraw(t *testing.T) {
+	request := &RequestToPayRequest{
+		Amount:       NewAmount(500),
+		Currency:     "EUR",
+		ExternalID:   "34232",
+		Payer:        Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+		PayerMessage: "cash out",
+		PayeeNote:    "agent 42",
+	}
+
+	for _, tt := range []struct {
+		name   string
+		urlStr string
+	}{
+		{name: "RequestToWithdraw", urlStr: collectionsRequestToWithdrawURL},
+		{name: "RequestToWithdrawV2", urlStr: collectionsRequestToWithdrawV2URL},
+	} {
+		t.Run(tt.name+" returns 202_ACCEPTED", func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc(tt.urlStr, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodPost)
+				body := RequestToPayRequest{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if body.Payer != request.Payer || !body.Amount.Equal(request.Amount) {
+					t.Errorf("Expected the request body to be sent but got %#v", body)
+				}
+				w.WriteHeader(http.StatusAccepted)
+			})
+			fn := client.Collection.RequestToWithdraw
+			if tt.name == "RequestToWithdrawV2" {
+				fn = client.Collection.RequestToWithdrawV2
+			}
+			referenceID, err := fn(ctx, request)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if referenceID == "" {
+				t.Errorf("Expected referenceID to be a non empty string")
+			}
+		})
+	}
+}
+
+func TestCollectionServiceOp_GetWithdrawStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expectedStatus := PaymentStatusResponse{
+		Amount:                 MustParseAmount("500"),
+		Currency:               "EUR",
+		FinancialTransactionID: "2312",
+		ExternalID:             "34232",
+		Payer: Party{
+			PartyIDType: PartyIDTypeMSISDN,
+			PartyID:     "25678999720",
+		},
+		Status: StatusSuccessful,
+	}
+
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	urlStr := fmt.Sprintf("%s/%s", collectionsRequestToWithdrawURL, referenceID)
+
+	mux.HandleFunc(urlStr, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		json.NewEncoder(w).Encode(expectedStatus)
+	})
+
+	actualStatus, err := client.Collection.GetWithdrawStatus(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !reflect.DeepEqual(actualStatus, &expectedStatus) {
+		t.Errorf("GetWithdrawStatus\n got=%#v\nwant=%#v", actualStatus, expectedStatus)
+	}
+}
+
+func TestCollectionServiceOp_SendDeliveryNotification(t *testing.T) {
+	t.Run("SendDeliveryNotification returns 200_OK", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+		urlStr := fmt.Sprintf("%s/%s/deliverynotification", collectionsRequestToPayURL, referenceID)
+
+		mux.HandleFunc(urlStr, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			testHeaders(t, r, headers{"Language": "en"})
+			body := map[string]string{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if body["notificationMessage"] != "Please approve your payment" {
+				t.Errorf("Unexpected notificationMessage %q", body["notificationMessage"])
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		err := client.Collection.SendDeliveryNotification(ctx, referenceID, "Please approve your payment", "en")
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+	})
+
+	t.Run("SendDeliveryNotification rejects a message longer than 160 characters", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		err := client.Collection.SendDeliveryNotification(ctx, "6c6eb16c-8b34-4d5d-bd41-2a9303f65075", strings.Repeat("a", 161), "en")
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+	})
 }
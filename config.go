@@ -0,0 +1,73 @@
+package gomomo
+
+import (
+	"net/url"
+)
+
+// Credentials holds the keys issued for a single Momo product. The SubscriptionKey is the product's
+// Primary Key from the developer portal, the UserID and APIKey identify the API user.
+type Credentials struct {
+	SubscriptionKey string
+	UserID          string
+	APIKey          string
+}
+
+// Config holds the settings for a Client that drives one or more Momo products. An empty BaseURL
+// or Environment leaves the sandbox defaults of NewClient in place. Products whose Credentials are
+// left empty fall back to the Client's SubscriptionKey and Token.
+type Config struct {
+	BaseURL     string
+	Environment string
+	// SubscriptionKey is sent to products whose Credentials have no SubscriptionKey of their own
+	SubscriptionKey string
+	Collection      Credentials
+	Disbursement    Credentials
+	Remittance      Credentials
+}
+
+// NewClientWithConfig returns a new Momo API client which sends each product's own subscription key
+// and access token. Further options are applied after the Config.
+func NewClientWithConfig(cfg Config, opts ...Option) (*Client, error) {
+	configOpts := []Option{
+		WithCredentials(ProductCollection, cfg.Collection),
+		WithCredentials(ProductDisbursement, cfg.Disbursement),
+		WithCredentials(ProductRemittance, cfg.Remittance),
+	}
+	if cfg.SubscriptionKey != "" {
+		configOpts = append(configOpts, WithSubscriptionKey(cfg.SubscriptionKey))
+	}
+	if cfg.BaseURL != "" {
+		configOpts = append(configOpts, WithBaseURL(cfg.BaseURL))
+	}
+	if cfg.Environment != "" {
+		configOpts = append(configOpts, WithEnvironment(cfg.Environment))
+	}
+	return NewClient(append(configOpts, opts...)...)
+}
+
+// SetCredentials replaces the subscription key and API user used for requests to the given product.
+// An empty SubscriptionKey falls back to the Client's SubscriptionKey and an empty UserID clears the
+// API user, so that requests fall back to the Client's Token. It is safe to call while requests are sent.
+func (c *Client) SetCredentials(p Product, creds Credentials) {
+	c.keysMu.Lock()
+	if creds.SubscriptionKey != "" {
+		c.subscriptionKeys[p] = creds.SubscriptionKey
+	} else {
+		delete(c.subscriptionKeys, p)
+	}
+	c.keysMu.Unlock()
+
+	if ts := c.TokenSource(p); ts != nil {
+		ts.SetCredentials(creds.UserID, creds.APIKey)
+	}
+}
+
+// subscriptionKey returns the subscription key to send with a request to the given URL
+func (c *Client) subscriptionKey(u *url.URL) string {
+	c.keysMu.RLock()
+	defer c.keysMu.RUnlock()
+	if key, ok := c.subscriptionKeys[productFromPath(u.Path)]; ok {
+		return key
+	}
+	return c.SubscriptionKey
+}
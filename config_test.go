@@ -0,0 +1,133 @@
+package gomomo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewClientWithConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClientWithConfig(Config{
+		BaseURL:     server.URL,
+		Environment: "sandbox",
+		Collection: Credentials{
+			SubscriptionKey: "collection-key",
+			UserID:          "collection-user",
+			APIKey:          "collection-api-key",
+		},
+		Disbursement: Credentials{
+			SubscriptionKey: "disbursement-key",
+			UserID:          "disbursement-user",
+			APIKey:          "disbursement-api-key",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	for _, p := range []Product{ProductCollection, ProductDisbursement} {
+		product := string(p)
+		mux.HandleFunc(fmt.Sprintf("/%s/token/", product), func(w http.ResponseWriter, r *http.Request) {
+			testHeaders(t, r, headers{"Ocp-Apim-Subscription-Key": product + "-key"})
+			if user, _, _ := r.BasicAuth(); user != product+"-user" {
+				t.Errorf("Expected API user %s-user but got %s", product, user)
+			}
+			fmt.Fprintf(w, `{"access_token": "%s-token", "token_type": "access_token", "expires_in": 3600}`, product)
+		})
+		mux.HandleFunc(fmt.Sprintf("/%s/v1_0/account/balance", product), func(w http.ResponseWriter, r *http.Request) {
+			testHeaders(t, r, headers{
+				"Ocp-Apim-Subscription-Key": product + "-key",
+				"Authorization":             "Bearer " + product + "-token",
+			})
+			fmt.Fprint(w, `{"availableBalance": "500", "currency": "EUR"}`)
+		})
+	}
+
+	if _, err := client.Collection.GetBalance(ctx); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if _, err := client.Disbursement.GetBalance(ctx); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if client.Token != "" {
+		t.Errorf("Expected the shared client Token to be left unset but got %s", client.Token)
+	}
+}
+
+func TestNewClientWithConfig_SubscriptionKey(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClientWithConfig(Config{
+		BaseURL:         server.URL,
+		SubscriptionKey: "shared-key",
+		Collection:      Credentials{SubscriptionKey: "collection-key", UserID: "collection-user", APIKey: "collection-api-key"},
+		Remittance:      Credentials{UserID: "remittance-user", APIKey: "remittance-api-key"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	expected := map[Product]string{ProductCollection: "collection-key", ProductRemittance: "shared-key"}
+	for p, key := range expected {
+		product, key := string(p), key
+		mux.HandleFunc(fmt.Sprintf("/%s/token/", product), func(w http.ResponseWriter, r *http.Request) {
+			testHeaders(t, r, headers{"Ocp-Apim-Subscription-Key": key})
+			fmt.Fprintf(w, `{"access_token": "%s-token", "token_type": "access_token", "expires_in": 3600}`, product)
+		})
+		mux.HandleFunc(fmt.Sprintf("/%s/v1_0/account/balance", product), func(w http.ResponseWriter, r *http.Request) {
+			testHeaders(t, r, headers{"Ocp-Apim-Subscription-Key": key})
+			fmt.Fprint(w, `{"availableBalance": "500", "currency": "EUR"}`)
+		})
+	}
+
+	if _, err := client.Collection.GetBalance(ctx); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if _, err := client.Remittance.GetBalance(ctx); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
+
+func TestClient_SetCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc(remittancesBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"availableBalance": "500", "currency": "EUR"}`)
+	})
+
+	t.Run("SetCredentials is safe while requests are sent", func(t *testing.T) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 50; i++ {
+				client.SetCredentials(ProductRemittance, Credentials{SubscriptionKey: fmt.Sprintf("key-%d", i)})
+			}
+		}()
+		for i := 0; i < 20; i++ {
+			if _, err := client.Remittance.GetBalance(ctx); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+		}
+		<-done
+	})
+
+	t.Run("Empty credentials clear the product's own key and API user", func(t *testing.T) {
+		client.SetCredentials(ProductRemittance, Credentials{SubscriptionKey: "remittance-key", UserID: "user", APIKey: "key"})
+		client.SetCredentials(ProductRemittance, Credentials{})
+
+		if client.TokenSource(ProductRemittance).hasCredentials() {
+			t.Errorf("Expected the API user to be cleared")
+		}
+		u, _ := client.BaseURL.Parse(remittancesBalanceURL)
+		if key := client.subscriptionKey(u); key != client.SubscriptionKey {
+			t.Errorf("Expected the Client's subscription key %q but got %q", client.SubscriptionKey, key)
+		}
+	})
+}
@@ -0,0 +1,344 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	collectionsBCAuthorizeURL      = "/collection/v1_0/bc-authorize"
+	collectionsOAuth2TokenURL      = "/collection/oauth2/token/"
+	collectionsUserInfoURL         = "/collection/oauth2/v1_0/userinfo"
+	disbursementsBCAuthorizeURL    = "/disbursement/v1_0/bc-authorize"
+	disbursementsOAuth2TokenURL    = "/disbursement/oauth2/token/"
+	disbursementsUserInfoURL       = "/disbursement/oauth2/v1_0/userinfo"
+	cibaGrantType                  = "urn:openid:params:grant-type:ciba"
+	defaultConsentPollInterval     = 5 * time.Second
+	consentSlowDownStep            = 5 * time.Second
+	oauthErrorAuthorizationPending = "authorization_pending"
+	oauthErrorSlowDown             = "slow_down"
+)
+
+// ErrAuthorizationPending is returned by GetUserToken while the account holder has not yet given their consent
+var ErrAuthorizationPending = errors.New("gomomo: authorization pending")
+
+// ErrSlowDown is returned by GetUserToken when Momo asks for it to be called less often. It matches
+// ErrAuthorizationPending with errors.Is, as consent has not been given yet either.
+var ErrSlowDown = fmt.Errorf("%w: slow down", ErrAuthorizationPending)
+
+// AccessType is the kind of access requested from an account holder
+type AccessType string
+
+// Access types of an authorization request
+const (
+	// AccessTypeOnline grants access while the account holder is present
+	AccessTypeOnline AccessType = "online"
+	// AccessTypeOffline grants access with a refresh token, without the account holder being present
+	AccessTypeOffline AccessType = "offline"
+)
+
+// AuthorizeRequest asks an account holder for consent to access their data
+type AuthorizeRequest struct {
+	// Party is the account holder whose consent is requested
+	Party Party
+	// Scope is the space separated list of scopes requested, e.g. "profile"
+	Scope      string
+	AccessType AccessType
+
+	// CallbackURL is where Momo sends the outcome of the authorization, sent as X-Callback-Url
+	CallbackURL string
+}
+
+// Validate checks that the request has a party, a scope and a known access type
+func (r *AuthorizeRequest) Validate() error {
+	if err := r.Party.Validate(); err != nil {
+		return fmt.Errorf("party: %v", err)
+	}
+	if r.Scope == "" {
+		return errors.New("scope is required")
+	}
+	if r.AccessType != AccessTypeOnline && r.AccessType != AccessTypeOffline {
+		return fmt.Errorf("invalid access type %q", r.AccessType)
+	}
+	return nil
+}
+
+func (r *AuthorizeRequest) form() url.Values {
+	return url.Values{
+		"login_hint":  {fmt.Sprintf("ID:%s/%s", r.Party.PartyID, r.Party.PartyIDType)},
+		"scope":       {r.Scope},
+		"access_type": {string(r.AccessType)},
+	}
+}
+
+// AuthorizeResponse identifies a pending authorization request
+type AuthorizeResponse struct {
+	AuthReqID string `json:"auth_req_id"`
+	// Interval is the number of seconds to wait between calls to GetUserToken
+	Interval int64 `json:"interval"`
+	// ExpiresIn is the number of seconds after which the authorization request expires
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// UserToken is an access token granted by an account holder
+type UserToken struct {
+	AccessToken           string `json:"access_token"`
+	TokenType             string `json:"token_type"`
+	ExpiresIn             int64  `json:"expires_in"`
+	Scope                 string `json:"scope"`
+	RefreshToken          string `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresIn int64  `json:"refresh_token_expired_in,omitempty"`
+}
+
+// UserInfo holds the personal information of an account holder that gave their consent
+type UserInfo struct {
+	Sub                 string `json:"sub"`
+	Name                string `json:"name"`
+	GivenName           string `json:"given_name"`
+	FamilyName          string `json:"family_name"`
+	MiddleName          string `json:"middle_name,omitempty"`
+	Email               string `json:"email,omitempty"`
+	EmailVerified       bool   `json:"email_verified,omitempty"`
+	Gender              string `json:"gender,omitempty"`
+	Locale              string `json:"locale,omitempty"`
+	PhoneNumber         string `json:"phone_number,omitempty"`
+	PhoneNumberVerified bool   `json:"phone_number_verified,omitempty"`
+	Address             string `json:"address,omitempty"`
+	UpdatedAt           int64  `json:"updated_at,omitempty"`
+	Status              string `json:"status,omitempty"`
+	Birthdate           string `json:"birthdate,omitempty"`
+	CreditScore         string `json:"credit_score,omitempty"`
+	Active              bool   `json:"active,omitempty"`
+	CountryOfBirth      string `json:"country_of_birth,omitempty"`
+	RegionOfBirth       string `json:"region_of_birth,omitempty"`
+	CityOfBirth         string `json:"city_of_birth,omitempty"`
+	Occupation          string `json:"occupation,omitempty"`
+	EmployerName        string `json:"employer_name,omitempty"`
+	IdentificationType  string `json:"identification_type,omitempty"`
+	IdentificationValue string `json:"identification_value,omitempty"`
+}
+
+// consentURLs are the endpoints of the consent flow of a product
+type consentURLs struct {
+	product     Product
+	bcAuthorize string
+	oauth2Token string
+	userInfo    string
+}
+
+var (
+	collectionConsentURLs   = consentURLs{ProductCollection, collectionsBCAuthorizeURL, collectionsOAuth2TokenURL, collectionsUserInfoURL}
+	disbursementConsentURLs = consentURLs{ProductDisbursement, disbursementsBCAuthorizeURL, disbursementsOAuth2TokenURL, disbursementsUserInfoURL}
+)
+
+func (c *Client) authorize(ctx context.Context, urls consentURLs, r *AuthorizeRequest) (*AuthorizeResponse, error) {
+	ctx, cancel := c.withTimeout(ctx, OperationAuthorize)
+	defer cancel()
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, urls.bcAuthorize, r.form())
+	if err != nil {
+		return nil, err
+	}
+	if r.CallbackURL != "" {
+		req.Header.Set("X-Callback-Url", r.CallbackURL)
+	}
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	auth := &AuthorizeResponse{}
+	err = json.Unmarshal(res.Body, auth)
+	if err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+func (c *Client) getUserToken(ctx context.Context, urls consentURLs, authReqID string) (*UserToken, error) {
+	ctx, cancel := c.withTimeout(ctx, OperationGetUserToken)
+	defer cancel()
+
+	form := url.Values{
+		"grant_type":  {cibaGrantType},
+		"auth_req_id": {authReqID},
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, urls.oauth2Token, form)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.TokenSource(urls.product).setBasicAuth(req); err != nil {
+		return nil, err
+	}
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		switch oauthError(res) {
+		case oauthErrorAuthorizationPending:
+			return nil, ErrAuthorizationPending
+		case oauthErrorSlowDown:
+			return nil, ErrSlowDown
+		}
+		return nil, newErrorResponse(res)
+	}
+
+	token := &UserToken{}
+	err = json.Unmarshal(res.Body, token)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// oauthError returns the OAuth2 error code of res, or an empty string if it has none
+func oauthError(res *Response) string {
+	oauthErr := struct {
+		Error string `json:"error"`
+	}{}
+	if json.Unmarshal(res.Body, &oauthErr) != nil {
+		return ""
+	}
+	return oauthErr.Error
+}
+
+// awaitUserToken polls getUserToken until the account holder gives or refuses their consent. Without opts,
+// it polls at the interval requested by Momo until the authorization request expires. Every slow_down
+// answer adds the initial interval, and at least 5 seconds when polling at Momo's pace, to later delays.
+func (c *Client) awaitUserToken(ctx context.Context, urls consentURLs, auth *AuthorizeResponse, o *WaitOptions) (*UserToken, error) {
+	opts := o.withDefaults()
+	if o == nil {
+		opts.InitialInterval = defaultConsentPollInterval
+		if auth.Interval > 0 {
+			opts.InitialInterval = time.Duration(auth.Interval) * time.Second
+		}
+		opts.Multiplier = 1
+		opts.Jitter = 0
+		if auth.ExpiresIn > 0 {
+			opts.Timeout = time.Duration(auth.ExpiresIn) * time.Second
+		}
+	}
+	slowDownStep := opts.InitialInterval
+	if o == nil && slowDownStep < consentSlowDownStep {
+		slowDownStep = consentSlowDownStep
+	}
+	timeout := time.NewTimer(opts.Timeout)
+	defer timeout.Stop()
+
+	var slowDown time.Duration
+	for attempt := 0; ; attempt++ {
+		token, err := c.getUserToken(ctx, urls, auth.AuthReqID)
+		switch {
+		case err == nil:
+			return token, nil
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case err == ErrSlowDown:
+			slowDown += slowDownStep
+		case err != ErrAuthorizationPending && !IsRetryable(err):
+			return nil, err
+		}
+
+		delay := time.NewTimer(backoff(attempt, opts.InitialInterval, opts.MaxInterval, opts.Multiplier, opts.Jitter) + slowDown)
+		select {
+		case <-ctx.Done():
+			delay.Stop()
+			return nil, ctx.Err()
+		case <-timeout.C:
+			delay.Stop()
+			return nil, ErrWaitTimeout
+		case <-delay.C:
+		}
+	}
+}
+
+func (c *Client) getUserInfoWithConsent(ctx context.Context, urls consentURLs, userToken string) (*UserInfo, error) {
+	ctx, cancel := c.withTimeout(ctx, OperationGetUserInfoWithConsent)
+	defer cancel()
+	ctx = context.WithValue(ctx, userTokenKey, true)
+
+	req, err := c.newRequest(ctx, http.MethodGet, urls.userInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+userToken)
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	info := &UserInfo{}
+	err = json.Unmarshal(res.Body, info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Authorize asks an account holder for consent to access their data. Exchange the returned
+// authorization request for a token with GetUserToken or AwaitUserToken.
+func (c *CollectionServiceOp) Authorize(ctx context.Context, r *AuthorizeRequest) (*AuthorizeResponse, error) {
+	return c.client.authorize(ctx, collectionConsentURLs, r)
+}
+
+// GetUserToken exchanges an authorization request for a token once the account holder has given their
+// consent. It returns ErrAuthorizationPending until they have.
+func (c *CollectionServiceOp) GetUserToken(ctx context.Context, authReqID string) (*UserToken, error) {
+	return c.client.getUserToken(ctx, collectionConsentURLs, authReqID)
+}
+
+// AwaitUserToken polls GetUserToken until the account holder gives or refuses their consent. A nil opts
+// polls at the interval requested by Momo until the authorization request expires, with ErrWaitTimeout.
+func (c *CollectionServiceOp) AwaitUserToken(ctx context.Context, auth *AuthorizeResponse, opts *WaitOptions) (*UserToken, error) {
+	return c.client.awaitUserToken(ctx, collectionConsentURLs, auth, opts)
+}
+
+// GetUserInfoWithConsent retrieves the personal information of the account holder that granted userToken
+func (c *CollectionServiceOp) GetUserInfoWithConsent(ctx context.Context, userToken string) (*UserInfo, error) {
+	return c.client.getUserInfoWithConsent(ctx, collectionConsentURLs, userToken)
+}
+
+// Authorize asks an account holder for consent to access their data. Exchange the returned
+// authorization request for a token with GetUserToken or AwaitUserToken.
+func (c *DisbursementServiceOp) Authorize(ctx context.Context, r *AuthorizeRequest) (*AuthorizeResponse, error) {
+	return c.client.authorize(ctx, disbursementConsentURLs, r)
+}
+
+// GetUserToken exchanges an authorization request for a token once the account holder has given their
+// consent. It returns ErrAuthorizationPending until they have.
+func (c *DisbursementServiceOp) GetUserToken(ctx context.Context, authReqID string) (*UserToken, error) {
+	return c.client.getUserToken(ctx, disbursementConsentURLs, authReqID)
+}
+
+// AwaitUserToken polls GetUserToken until the account holder gives or refuses their consent. A nil opts
+// polls at the interval requested by Momo until the authorization request expires, with ErrWaitTimeout.
+func (c *DisbursementServiceOp) AwaitUserToken(ctx context.Context, auth *AuthorizeResponse, opts *WaitOptions) (*UserToken, error) {
+	return c.client.awaitUserToken(ctx, disbursementConsentURLs, auth, opts)
+}
+
+// GetUserInfoWithConsent retrieves the personal information of the account holder that granted userToken
+func (c *DisbursementServiceOp) GetUserInfoWithConsent(ctx context.Context, userToken string) (*UserInfo, error) {
+	return c.client.getUserInfoWithConsent(ctx, disbursementConsentURLs, userToken)
+}
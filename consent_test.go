@@ -0,0 +1,187 @@
+package gomomo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// setupConsent sets the API user of product and serves its access token from tokenURL
+func setupConsent(product Product, tokenURL string) {
+	client.TokenSource(product).SetCredentials("user", "key")
+	mux.HandleFunc(tokenURL, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token": "client-token", "token_type": "access_token", "expires_in": 3600}`)
+	})
+}
+
+func TestCollectionServiceOp_Authorize(t *testing.T) {
+	setup()
+	defer teardown()
+	setupConsent(ProductCollection, collectionsTokenURL)
+
+	mux.HandleFunc(collectionsBCAuthorizeURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testHeaders(t, r, headers{
+			"Content-Type":   "application/x-www-form-urlencoded",
+			"Authorization":  "Bearer client-token",
+			"X-Callback-Url": "https://example.com/momo/consent",
+		})
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		expected := map[string]string{"login_hint": "ID:46733123450/MSISDN", "scope": "profile", "access_type": "offline"}
+		for k, v := range expected {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("Expected %s to be %q but got %q", k, v, got)
+			}
+		}
+		fmt.Fprint(w, `{"auth_req_id": "41ce8f9c", "interval": 5, "expires_in": 3600}`)
+	})
+
+	auth, err := client.Collection.Authorize(ctx, &AuthorizeRequest{
+		Party:       Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "46733123450"},
+		Scope:       "profile",
+		AccessType:  AccessTypeOffline,
+		CallbackURL: "https://example.com/momo/consent",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	expected := &AuthorizeResponse{AuthReqID: "41ce8f9c", Interval: 5, ExpiresIn: 3600}
+	if !reflect.DeepEqual(auth, expected) {
+		t.Errorf("Authorize\n got=%#v\nwant=%#v", auth, expected)
+	}
+
+	_, err = client.Collection.Authorize(ctx, &AuthorizeRequest{
+		Party:      Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "46733123450"},
+		Scope:      "profile",
+		AccessType: "forever",
+	})
+	if err == nil {
+		t.Errorf("Expected a non nil error for an invalid access type")
+	}
+}
+
+func TestCollectionServiceOp_AwaitUserToken(t *testing.T) {
+	setup()
+	defer teardown()
+	setupConsent(ProductCollection, collectionsTokenURL)
+
+	calls := 0
+	mux.HandleFunc(collectionsOAuth2TokenURL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		testMethod(t, r, http.MethodPost)
+		if user, key, ok := r.BasicAuth(); !ok || user != "user" || key != "key" {
+			t.Errorf("Expected basic auth user:key but got %s:%s", user, key)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if r.PostForm.Get("grant_type") != cibaGrantType || r.PostForm.Get("auth_req_id") != "41ce8f9c" {
+			t.Errorf("Unexpected form %v", r.PostForm)
+		}
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "authorization_pending"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "user-token", "token_type": "Bearer", "expires_in": 3600, "scope": "profile"}`)
+	})
+
+	t.Run("GetUserToken returns ErrAuthorizationPending", func(t *testing.T) {
+		_, err := client.Collection.GetUserToken(ctx, "41ce8f9c")
+		if err != ErrAuthorizationPending {
+			t.Errorf("Expected ErrAuthorizationPending but got %v", err)
+		}
+	})
+
+	t.Run("AwaitUserToken polls until consent is given", func(t *testing.T) {
+		auth := &AuthorizeResponse{AuthReqID: "41ce8f9c", Interval: 5, ExpiresIn: 3600}
+		token, err := client.Collection.AwaitUserToken(ctx, auth, &WaitOptions{InitialInterval: time.Millisecond, Timeout: time.Second})
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if token.AccessToken != "user-token" || calls != 3 {
+			t.Errorf("Expected user-token after 3 calls but got %#v after %d calls", token, calls)
+		}
+	})
+}
+
+func TestDisbursementServiceOp_AwaitUserToken(t *testing.T) {
+	setup()
+	defer teardown()
+	setupConsent(ProductDisbursement, disbursementsTokenURL)
+
+	calls := 0
+	mux.HandleFunc(disbursementsOAuth2TokenURL, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		testMethod(t, r, http.MethodPost)
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "slow_down"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "user-token", "token_type": "Bearer", "expires_in": 3600, "scope": "profile"}`)
+	})
+
+	t.Run("GetUserToken returns ErrSlowDown", func(t *testing.T) {
+		_, err := client.Disbursement.GetUserToken(ctx, "41ce8f9c")
+		if err != ErrSlowDown || !errors.Is(err, ErrAuthorizationPending) {
+			t.Errorf("Expected ErrSlowDown matching ErrAuthorizationPending but got %v", err)
+		}
+	})
+
+	t.Run("AwaitUserToken polls less often when asked to slow down", func(t *testing.T) {
+		calls = 1
+		auth := &AuthorizeResponse{AuthReqID: "41ce8f9c", Interval: 5, ExpiresIn: 3600}
+		start := time.Now()
+		token, err := client.Disbursement.AwaitUserToken(ctx, auth, &WaitOptions{
+			InitialInterval: 10 * time.Millisecond,
+			Multiplier:      1,
+			Jitter:          NoJitter,
+			Timeout:         time.Second,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if token.AccessToken != "user-token" {
+			t.Errorf("Expected user-token but got %#v", token)
+		}
+		// The one slow_down grows the single 10ms delay by another 10ms
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("Expected the delay to grow after slow_down but polling took %s", elapsed)
+		}
+	})
+}
+
+func TestCollectionServiceOp_GetUserInfoWithConsent(t *testing.T) {
+	setup()
+	defer teardown()
+	setupConsent(ProductCollection, collectionsTokenURL)
+
+	mux.HandleFunc(collectionsUserInfoURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"sub": "0", "name": "Sand Box", "given_name": "Sand", "family_name": "Box", "birthdate": "1976-08-13", "locale": "sv_SE"}`)
+	})
+
+	info, err := client.Collection.GetUserInfoWithConsent(ctx, "user-token")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	expected := &UserInfo{Sub: "0", Name: "Sand Box", GivenName: "Sand", FamilyName: "Box", Birthdate: "1976-08-13", Locale: "sv_SE"}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("GetUserInfoWithConsent\n got=%#v\nwant=%#v", info, expected)
+	}
+
+	_, err = client.Collection.GetUserInfoWithConsent(ctx, "expired-token")
+	if !IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error but got %v", err)
+	}
+}
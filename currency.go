@@ -0,0 +1,61 @@
+package gomomo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrCurrencyNotAllowed is returned when a request uses a currency rejected by the CurrencyRule
+// of the Client's target environment
+var ErrCurrencyNotAllowed = errors.New("gomomo: currency not allowed in target environment")
+
+// CurrencyRule describes the currencies accepted in a target environment
+type CurrencyRule struct {
+	// Currencies lists the accepted currencies. An empty list accepts any ISO 4217 currency.
+	Currencies []string
+	// Coerce rewrites any other currency to Currencies[0] instead of rejecting the request
+	Coerce bool
+}
+
+// SandboxCurrencyRule only accepts EUR, the one currency supported by the Momo sandbox.
+// It rejects any other currency rather than rewriting it.
+var SandboxCurrencyRule = CurrencyRule{Currencies: []string{"EUR"}}
+
+// SetCurrencyRule sets the rule applied to the currency of payments and transfers sent to the given environment
+func (c *Client) SetCurrencyRule(environment string, rule CurrencyRule) {
+	if c.CurrencyRules == nil {
+		c.CurrencyRules = map[string]CurrencyRule{}
+	}
+	c.CurrencyRules[environment] = rule
+}
+
+// EnableSandboxCurrencyCoercion makes payments and transfers sent to the sandbox use EUR whatever
+// currency they were created with. Every rewrite is reported to CurrencyCoerced, or logged when it is not set.
+func (c *Client) EnableSandboxCurrencyCoercion() {
+	c.SetCurrencyRule("sandbox", CurrencyRule{Currencies: []string{"EUR"}, Coerce: true})
+}
+
+// applyCurrencyRule returns the currency to send for the Client's environment
+func (c *Client) applyCurrencyRule(currency string) (string, error) {
+	rule, ok := c.CurrencyRules[c.Environment]
+	if !ok || len(rule.Currencies) == 0 {
+		return currency, nil
+	}
+	for _, allowed := range rule.Currencies {
+		if currency == allowed {
+			return currency, nil
+		}
+	}
+	if !rule.Coerce {
+		return "", fmt.Errorf("%w: %s is not accepted in %s", ErrCurrencyNotAllowed, currency, c.Environment)
+	}
+
+	coerced := rule.Currencies[0]
+	if c.CurrencyCoerced != nil {
+		c.CurrencyCoerced(c.Environment, currency, coerced)
+	} else {
+		log.Printf("gomomo: currency %s rewritten to %s for the %s environment", currency, coerced, c.Environment)
+	}
+	return coerced, nil
+}
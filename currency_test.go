@@ -0,0 +1,66 @@
+package gomomo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_CurrencyRules(t *testing.T) {
+	t.Run("Currency is sent unchanged without a rule", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		mux.HandleFunc(collectionsRequestToPayURL, func(w http.ResponseWriter, r *http.Request) {
+			body := RequestToPayRequest{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Currency != "UGX" {
+				t.Errorf("Expected currency UGX but got %s", body.Currency)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+		_, err := client.Collection.RequestToPay(ctx, "25678999720", 500, "34232", "payee", "payer", "UGX")
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+	})
+
+	t.Run("A strict rule rejects other currencies", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		client.SetCurrencyRule("sandbox", SandboxCurrencyRule)
+		_, err := client.Disbursement.Transfer(ctx, "25678999720", 500, "34232", "payee", "payer", "UGX")
+		if !errors.Is(err, ErrCurrencyNotAllowed) {
+			t.Errorf("Expected ErrCurrencyNotAllowed but got %v", err)
+		}
+	})
+
+	t.Run("Sandbox coercion rewrites the currency and reports it", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		mux.HandleFunc(remittancesTransferURL, func(w http.ResponseWriter, r *http.Request) {
+			body := TransferRequest{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Currency != "EUR" {
+				t.Errorf("Expected currency EUR but got %s", body.Currency)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+		var coerced []string
+		client.CurrencyCoerced = func(environment, from, to string) {
+			coerced = append(coerced, environment, from, to)
+		}
+		client.EnableSandboxCurrencyCoercion()
+
+		_, err := client.Remittance.Transfer(ctx, "25678999720", 500, "34232", "payee", "payer", "UGX")
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if len(coerced) != 3 || coerced[0] != "sandbox" || coerced[1] != "UGX" || coerced[2] != "EUR" {
+			t.Errorf("Expected the coercion from UGX to EUR to be reported but got %v", coerced)
+		}
+	})
+}
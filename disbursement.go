@@ -8,19 +8,38 @@ import (
 )
 
 const (
-	disbursementsTokenURL           = "/disbursement/token/"
-	disbursementsTransferURL        = "/disbursement/v1_0/transfer"
-	disbursementsBalanceURL         = "/disbursement/v1_0/account/balance"
-	disbursementsIsAccountActiveURL = "/disbursement/v1_0/accountholder/msisdn/"
+	disbursementsTokenURL     = "/disbursement/token/"
+	disbursementsTransferURL  = "/disbursement/v1_0/transfer"
+	disbursementsDepositURL   = "/disbursement/v1_0/deposit"
+	disbursementsDepositV2URL = "/disbursement/v2_0/deposit"
+	disbursementsRefundURL    = "/disbursement/v1_0/refund"
+	disbursementsRefundV2URL  = "/disbursement/v2_0/refund"
+	disbursementsBalanceURL   = "/disbursement/v1_0/account/balance"
 )
 
 // DisbursementService handles communication with Disbursement related methods of the
 // Momo API to automatically deposit funds into multiple users accounts
 type DisbursementService interface {
 	Transfer(ctx context.Context, mobileNumber string, amount int64, id, payeeNote, payerMessage, currency string) (string, error)
+	CreateTransfer(ctx context.Context, r *TransferRequest) (string, error)
 	GetTransfer(ctx context.Context, transactionID string) (*PaymentStatusResponse, error)
+	WaitForTransfer(ctx context.Context, transferID string, opts *WaitOptions) (*PaymentStatusResponse, error)
+	Deposit(ctx context.Context, r *TransferRequest) (string, error)
+	DepositV2(ctx context.Context, r *TransferRequest) (string, error)
+	GetDeposit(ctx context.Context, referenceID string) (*PaymentStatusResponse, error)
+	Refund(ctx context.Context, r *RefundRequest) (string, error)
+	RefundV2(ctx context.Context, r *RefundRequest) (string, error)
+	GetRefund(ctx context.Context, referenceID string) (*PaymentStatusResponse, error)
 	GetBalance(ctx context.Context) (*BalanceResponse, error)
+	GetBalanceInCurrency(ctx context.Context, currency string) (*BalanceResponse, error)
+	GetBalances(ctx context.Context, currencies []string) (map[string]*BalanceResponse, error)
 	IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error)
+	ValidateAccountHolder(ctx context.Context, party Party) (AccountStatus, error)
+	GetBasicUserInfo(ctx context.Context, party Party) (*BasicUserInfo, error)
+	Authorize(ctx context.Context, r *AuthorizeRequest) (*AuthorizeResponse, error)
+	GetUserToken(ctx context.Context, authReqID string) (*UserToken, error)
+	AwaitUserToken(ctx context.Context, auth *AuthorizeResponse, opts *WaitOptions) (*UserToken, error)
+	GetUserInfoWithConsent(ctx context.Context, userToken string) (*UserInfo, error)
 	GetToken(ctx context.Context, apiKey, userID string) (string, error)
 }
 
@@ -31,109 +50,141 @@ type DisbursementServiceOp struct {
 
 // GetBalance returns the balance of the account
 func (c *DisbursementServiceOp) GetBalance(ctx context.Context) (*BalanceResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodGet, disbursementsBalanceURL, nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.client.getBalance(ctx, disbursementsBalanceURL)
+}
 
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return nil, err
-	}
+// IsPayeeActive checks if an account holder identified by their mobile number is registered and active in the system.
+// It is a shorthand for ValidateAccountHolder.
+func (c *DisbursementServiceOp) IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error) {
+	status, err := c.ValidateAccountHolder(ctx, Party{PartyIDType: PartyIDTypeMSISDN, PartyID: mobileNumber})
+	return status == AccountStatusActive, err
+}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
+// GetToken creates an access token which can then be used to authorize and authenticate towards the other end-points of the Disbursement API.
+// The credentials are kept on the product's TokenSource, so subsequent requests fetch and refresh tokens automatically.
+func (c *DisbursementServiceOp) GetToken(ctx context.Context, apiKey, userID string) (string, error) {
+	ts := c.client.TokenSource(ProductDisbursement)
+	ts.SetCredentials(userID, apiKey)
+	return ts.Token(ctx)
+}
 
-	balance := &BalanceResponse{}
-	err = json.Unmarshal(res.Body, balance)
-	if err != nil {
-		return nil, err
-	}
-	return balance, nil
+// Transfer operation is used to transfer an amount from the owner’s account to a payee identified by their mobile number.
+// It is a shorthand for CreateTransfer.
+func (c *DisbursementServiceOp) Transfer(ctx context.Context, mobileNumber string, amount int64, id, payeeNote, payerMessage, currency string) (string, error) {
+	return c.CreateTransfer(ctx, &TransferRequest{
+		Amount:     NewAmount(amount),
+		Currency:   currency,
+		ExternalID: id,
+		Payee: Party{
+			PartyIDType: PartyIDTypeMSISDN,
+			PartyID:     mobileNumber,
+		},
+		PayerMessage: payerMessage,
+		PayeeNote:    payeeNote,
+	})
 }
 
-// IsPayeeActive checks if an account holder is registered and active in the system
-func (c *DisbursementServiceOp) IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error) {
-	urlStr := fmt.Sprintf("%s/%s/active", disbursementsIsAccountActiveURL, mobileNumber)
-	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
-	if err != nil {
-		return false, err
-	}
+// CreateTransfer is used to transfer an amount from the owner’s account to a payee account. Set ReferenceID on the
+// request or use WithReferenceID on ctx to resend a transfer safely; a transfer whose reference ID already exists
+// is reported as a success.
+func (c *DisbursementServiceOp) CreateTransfer(ctx context.Context, r *TransferRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationTransfer)
+	defer cancel()
 
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return false, err
-	}
+	return c.client.sendTransfer(ctx, disbursementsTransferURL, r, c.GetTransfer)
+}
 
-	if res.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
+// GetTransfer retrieves transfer information using the transactionId returned by Transfer
+func (c *DisbursementServiceOp) GetTransfer(ctx context.Context, transferID string) (*PaymentStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetTransfer)
+	defer cancel()
 
-	return true, nil
+	return c.transferStatus(ctx, fmt.Sprintf("%s/%s", disbursementsTransferURL, transferID))
 }
 
-// GetToken creates an access token which can then be used to authorize and authenticate towards the other end-points of the Disbursement API
-func (c *DisbursementServiceOp) GetToken(ctx context.Context, apiKey, userID string) (string, error) {
+// Deposit is used to deposit an amount from the owner’s account to a payee account using the v1_0 API
+func (c *DisbursementServiceOp) Deposit(ctx context.Context, r *TransferRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationDeposit)
+	defer cancel()
 
-	req, err := c.client.NewRequest(ctx, http.MethodPost, disbursementsTokenURL, nil)
-	if err != nil {
-		return "", err
-	}
-	req.SetBasicAuth(userID, apiKey)
+	return c.client.sendTransfer(ctx, disbursementsDepositURL, r, c.GetDeposit)
+}
 
-	res, err := c.client.Do(ctx, req)
+// DepositV2 is used to deposit an amount from the owner’s account to a payee account using the v2_0 API
+func (c *DisbursementServiceOp) DepositV2(ctx context.Context, r *TransferRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationDeposit)
+	defer cancel()
+
+	return c.client.sendTransfer(ctx, disbursementsDepositV2URL, r, c.GetDeposit)
+}
+
+// GetDeposit retrieves deposit information using the reference ID returned by Deposit
+func (c *DisbursementServiceOp) GetDeposit(ctx context.Context, referenceID string) (*PaymentStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetDeposit)
+	defer cancel()
+
+	return c.transferStatus(ctx, fmt.Sprintf("%s/%s", disbursementsDepositURL, referenceID))
+}
+
+// Refund is used to refund a collected payment, identified by its ReferenceIDToRefund, using the v1_0 API
+func (c *DisbursementServiceOp) Refund(ctx context.Context, r *RefundRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationRefund)
+	defer cancel()
+
+	return c.sendRefund(ctx, disbursementsRefundURL, r)
+}
+
+// RefundV2 is used to refund a collected payment, identified by its ReferenceIDToRefund, using the v2_0 API
+func (c *DisbursementServiceOp) RefundV2(ctx context.Context, r *RefundRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationRefund)
+	defer cancel()
+
+	return c.sendRefund(ctx, disbursementsRefundV2URL, r)
+}
+
+// GetRefund retrieves refund information using the reference ID returned by Refund
+func (c *DisbursementServiceOp) GetRefund(ctx context.Context, referenceID string) (*PaymentStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetRefund)
+	defer cancel()
+
+	return c.transferStatus(ctx, fmt.Sprintf("%s/%s", disbursementsRefundURL, referenceID))
+}
+
+// sendTransfer sends r to urlStr and returns its reference ID. A reference ID that already
+// exists is confirmed with lookup. It is shared by Disbursement and Remittance.
+func (c *Client) sendTransfer(ctx context.Context, urlStr string, r *TransferRequest, lookup func(ctx context.Context, referenceID string) (*PaymentStatusResponse, error)) (string, error) {
+	err := r.Validate()
 	if err != nil {
 		return "", err
 	}
-	token := &tokenResponse{}
 
-	err = json.Unmarshal(res.Body, token)
+	requestBody := *r
+	requestBody.Currency, err = c.applyCurrencyRule(r.Currency)
 	if err != nil {
 		return "", err
 	}
-	c.client.Token = token.AccessToken
-	return token.AccessToken, err
-}
-
-// Transfer operation is used to transfer an amount from the owner’s account to a payee account.
-func (c *DisbursementServiceOp) Transfer(ctx context.Context, mobileNumber string, amount int64, id, payeeNote, payerMessage, currency string) (string, error) {
-	if c.client.Environment == "sandbox" {
-		currency = "EUR"
-	}
 
-	requestBody := transferRequestBody{
-		Amount:     amount,
-		Currency:   currency,
-		ExternalID: id,
-		Payee: paymentDetails{
-			PartyIDType: "MSISDN",
-			PartyID:     mobileNumber,
-		},
-		PayerMessage: payerMessage,
-		PayeeNote:    payeeNote,
-	}
+	return c.create(ctx, urlStr, requestBody, r.ReferenceID, r.CallbackURL, paymentLookup(lookup))
+}
 
-	req, err := c.client.NewRequest(ctx, http.MethodPost, disbursementsTransferURL, requestBody)
+// sendRefund sends r to urlStr and returns its reference ID
+func (c *DisbursementServiceOp) sendRefund(ctx context.Context, urlStr string, r *RefundRequest) (string, error) {
+	err := r.Validate()
 	if err != nil {
 		return "", err
 	}
 
-	res, err := c.client.Do(ctx, req)
+	requestBody := *r
+	requestBody.Currency, err = c.client.applyCurrencyRule(r.Currency)
 	if err != nil {
 		return "", err
 	}
 
-	if res.StatusCode != http.StatusAccepted {
-		return "", fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
-
-	return req.Header.Get("X-Reference-Id"), nil
+	return c.client.create(ctx, urlStr, requestBody, r.ReferenceID, r.CallbackURL, paymentLookup(c.GetRefund))
 }
 
-// GetTransfer retrieves transfer information using the transactionId returned by Transfer
-func (c *DisbursementServiceOp) GetTransfer(ctx context.Context, transferID string) (*PaymentStatusResponse, error) {
-	urlStr := fmt.Sprintf("%s/%s", disbursementsTransferURL, transferID)
+// transferStatus retrieves the status of a transfer, deposit or refund
+func (c *DisbursementServiceOp) transferStatus(ctx context.Context, urlStr string) (*PaymentStatusResponse, error) {
 	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
 	if err != nil {
 		return nil, err
@@ -145,7 +196,7 @@ func (c *DisbursementServiceOp) GetTransfer(ctx context.Context, transferID stri
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
+		return nil, newErrorResponse(res)
 	}
 
 	status := &PaymentStatusResponse{}
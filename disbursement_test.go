@@ -1,6 +1,7 @@
 package gomomo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,8 +27,8 @@ func TestDisbursementServiceOp_GetToken(t *testing.T) {
 			t.Errorf("Expected 'token' but got %s", token)
 		}
 
-		if client.Token != token {
-			t.Errorf("Expected 'token' to be set on client but got %s", token)
+		if client.Token != "" {
+			t.Errorf("Expected the shared client Token to be left unset but got %s", client.Token)
 		}
 	})
 
@@ -66,16 +67,36 @@ func TestDisbursementServiceOp_Transfer(t *testing.T) {
 	})
 }
 
+func TestDisbursementServiceOp_Transfer_Conflict(t *testing.T) {
+	setup()
+	defer teardown()
+	referenceID := NewReferenceID()
+
+	mux.HandleFunc(disbursementsTransferURL, func(w http.ResponseWriter, r *http.Request) {
+		testHeaders(t, r, headers{"X-Reference-Id": referenceID})
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"code":"RESOURCE_ALREADY_EXIST","message":"Duplicated reference id. Creation of resource failed."}`)
+	})
+	mux.HandleFunc(disbursementsTransferURL+"/"+referenceID, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.Disbursement.Transfer(WithReferenceID(ctx, referenceID), "25678999720", 500, "34232", "payee", "payer", "UGX")
+	if !IsNotFound(err) {
+		t.Errorf("Expected a not found error when the conflicting transfer cannot be looked up but got %v", err)
+	}
+}
+
 func TestDisbursementServiceOp_GetTransfer(t *testing.T) {
 	setup()
 	defer teardown()
 
 	expectedStatus := PaymentStatusResponse{
-		Amount:                 "500",
+		Amount:                 MustParseAmount("500"),
 		Currency:               "UGX",
-		FinancialTransactionID: 2312,
+		FinancialTransactionID: "2312",
 		ExternalID:             "3232",
-		Payer: paymentDetails{
+		Payer: Party{
 			PartyIDType: "MSISDN",
 			PartyID:     "4656473839",
 		},
@@ -107,7 +128,7 @@ func TestDisbursementServiceOp_GetBalance(t *testing.T) {
 	defer teardown()
 
 	expectedBalance := BalanceResponse{
-		AvailableBalance: "500",
+		AvailableBalance: MustParseAmount("500"),
 		Currency:         "UGX",
 	}
 
@@ -130,15 +151,177 @@ func TestDisbursementServiceOp_IsPayeeActive(t *testing.T) {
 	setup()
 	defer teardown()
 	mobileNumber := "256789997290"
-	urlStr := fmt.Sprintf("%s%s/active", disbursementsIsAccountActiveURL, mobileNumber)
+	urlStr := fmt.Sprintf("%s/msisdn/%s/active", disbursementsAccountHolderURL, mobileNumber)
 
 	mux.HandleFunc(urlStr, func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
 		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"result": true}`)
 	})
 
-	_, err := client.Disbursement.IsPayeeActive(ctx, mobileNumber)
+	active, err := client.Disbursement.IsPayeeActive(ctx, mobileNumber)
 	if err != nil {
 		t.Fatalf("unexpected error %s", err)
 	}
+	if !active {
+		t.Errorf("Expected the payee to be active")
+	}
+}
+
+func TestDisbursementServiceOp_Deposit(t *testing.T) {
+	request := &TransferRequest{
+		Amount:       NewAmount(500),
+		Currency:     "EUR",
+		ExternalID:   "34232",
+		Payee:        Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+		PayerMessage: "salary",
+		PayeeNote:    "march",
+	}
+
+	for _, tt := range []struct {
+		name    string
+		urlStr  string
+		deposit func(ctx context.Context, r *TransferRequest) (string, error)
+	}{
+		{name: "Deposit", urlStr: disbursementsDepositURL, deposit: func(ctx context.Context, r *TransferRequest) (string, error) {
+			return client.Disbursement.Deposit(ctx, r)
+		}},
+		{name: "DepositV2", urlStr: disbursementsDepositV2URL, deposit: func(ctx context.Context, r *TransferRequest) (string, error) {
+			return client.Disbursement.DepositV2(ctx, r)
+		}},
+	} {
+		t.Run(tt.name+" returns 202_ACCEPTED", func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc(tt.urlStr, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodPost)
+				body := TransferRequest{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if body.Payee != request.Payee || !body.Amount.Equal(request.Amount) {
+					t.Errorf("Unexpected deposit body %+v", body)
+				}
+				w.WriteHeader(http.StatusAccepted)
+			})
+
+			referenceID, err := tt.deposit(ctx, request)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if referenceID == "" {
+				t.Errorf("Expected referenceID to be a non empty string")
+			}
+		})
+	}
+}
+
+func TestDisbursementServiceOp_GetDeposit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expectedStatus := PaymentStatusResponse{
+		Amount:                 MustParseAmount("500"),
+		Currency:               "EUR",
+		FinancialTransactionID: "2312",
+		ExternalID:             "34232",
+		Payee: Party{
+			PartyIDType: PartyIDTypeMSISDN,
+			PartyID:     "25678999720",
+		},
+		Status: StatusSuccessful,
+	}
+
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	mux.HandleFunc(fmt.Sprintf("%s/%s", disbursementsDepositURL, referenceID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		json.NewEncoder(w).Encode(expectedStatus)
+	})
+
+	actualStatus, err := client.Disbursement.GetDeposit(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !reflect.DeepEqual(actualStatus, &expectedStatus) {
+		t.Errorf("GetDeposit\n got=%#v\nwant=%#v", actualStatus, expectedStatus)
+	}
+}
+
+func TestDisbursementServiceOp_Refund(t *testing.T) {
+	request := &RefundRequest{
+		Amount:              NewAmount(500),
+		Currency:            "EUR",
+		ExternalID:          "34232",
+		PayerMessage:        "order cancelled",
+		PayeeNote:           "order 42",
+		ReferenceIDToRefund: "6c6eb16c-8b34-4d5d-bd41-2a9303f65075",
+	}
+
+	for _, tt := range []struct {
+		name   string
+		urlStr string
+		refund func(ctx context.Context, r *RefundRequest) (string, error)
+	}{
+		{name: "Refund", urlStr: disbursementsRefundURL, refund: func(ctx context.Context, r *RefundRequest) (string, error) {
+			return client.Disbursement.Refund(ctx, r)
+		}},
+		{name: "RefundV2", urlStr: disbursementsRefundV2URL, refund: func(ctx context.Context, r *RefundRequest) (string, error) {
+			return client.Disbursement.RefundV2(ctx, r)
+		}},
+	} {
+		t.Run(tt.name+" returns 202_ACCEPTED", func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc(tt.urlStr, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodPost)
+				body := RefundRequest{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if body.ReferenceIDToRefund != request.ReferenceIDToRefund {
+					t.Errorf("Expected referenceIdToRefund %s but got %s", request.ReferenceIDToRefund, body.ReferenceIDToRefund)
+				}
+				w.WriteHeader(http.StatusAccepted)
+			})
+
+			referenceID, err := tt.refund(ctx, request)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if referenceID == "" {
+				t.Errorf("Expected referenceID to be a non empty string")
+			}
+		})
+	}
+
+	t.Run("Refund rejects an invalid referenceIdToRefund", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		invalid := *request
+		invalid.ReferenceIDToRefund = "order-42"
+		if _, err := client.Disbursement.Refund(ctx, &invalid); err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+	})
+}
+
+func TestDisbursementServiceOp_GetRefund(t *testing.T) {
+	setup()
+	defer teardown()
+
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	mux.HandleFunc(fmt.Sprintf("%s/%s", disbursementsRefundURL, referenceID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"amount": "500", "currency": "EUR", "financialTransactionId": "2312", "externalId": "34232", "status": "FAILED", "reason": "PAYER_NOT_FOUND"}`)
+	})
+
+	status, err := client.Disbursement.GetRefund(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if status.Status != StatusFailed || status.Reason != "PAYER_NOT_FOUND" {
+		t.Errorf("Unexpected refund status %#v", status)
+	}
 }
@@ -0,0 +1,106 @@
+package gomomo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error codes returned by the Momo API in the body of failed requests
+const (
+	ErrorCodeResourceNotFound            = "RESOURCE_NOT_FOUND"
+	ErrorCodeResourceAlreadyExist        = "RESOURCE_ALREADY_EXIST"
+	ErrorCodePayerNotFound               = "PAYER_NOT_FOUND"
+	ErrorCodePayeeNotFound               = "PAYEE_NOT_FOUND"
+	ErrorCodeNotAllowed                  = "NOT_ALLOWED"
+	ErrorCodeNotAllowedTargetEnvironment = "NOT_ALLOWED_TARGET_ENVIRONMENT"
+	ErrorCodeInvalidCallbackURLHost      = "INVALID_CALLBACK_URL_HOST"
+	ErrorCodeInvalidCurrency             = "INVALID_CURRENCY"
+	ErrorCodeNotEnoughFunds              = "NOT_ENOUGH_FUNDS"
+	ErrorCodeInternalProcessingError     = "INTERNAL_PROCESSING_ERROR"
+	ErrorCodeServiceUnavailable          = "SERVICE_UNAVAILABLE"
+	ErrorCodeCouldNotPerformTransaction  = "COULD_NOT_PERFORM_TRANSACTION"
+)
+
+// Sentinel errors matched by an *ErrorResponse through errors.Is
+var (
+	ErrNotFound     = errors.New("gomomo: resource not found")
+	ErrConflict     = errors.New("gomomo: resource already exists")
+	ErrUnauthorized = errors.New("gomomo: unauthorized")
+	ErrRetryable    = errors.New("gomomo: retryable error")
+)
+
+// ErrorResponse is returned when the Momo API responds with an unexpected status code.
+// Code and Message are parsed from the response body when it holds a Momo error.
+type ErrorResponse struct {
+	Response    *Response `json:"-"`
+	StatusCode  int       `json:"-"`
+	ReferenceID string    `json:"-"`
+	Code        string    `json:"code"`
+	Message     string    `json:"message"`
+}
+
+func newErrorResponse(res *Response) error {
+	e := &ErrorResponse{
+		Response:    res,
+		StatusCode:  res.StatusCode,
+		ReferenceID: res.ReferenceID,
+	}
+	// The body is not always a Momo error, in which case only the status is known
+	_ = json.Unmarshal(res.Body, e)
+	return e
+}
+
+func (e *ErrorResponse) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("response code: %d with error %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("response code: %d with error %s", e.StatusCode, string(e.Response.Body))
+}
+
+// Is reports whether the error matches one of ErrNotFound, ErrConflict, ErrUnauthorized or ErrRetryable
+func (e *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.Code == ErrorCodeResourceNotFound ||
+			e.Code == ErrorCodePayerNotFound || e.Code == ErrorCodePayeeNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.Code == ErrorCodeResourceAlreadyExist
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrRetryable:
+		return isRetryableStatus(e.StatusCode) || e.Code == ErrorCodeServiceUnavailable
+	}
+	return false
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a Momo API error for a missing resource, payer or payee
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err is a Momo API error for a resource that already exists,
+// such as a duplicate reference ID
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsUnauthorized reports whether err is a Momo API error for a rejected access token or API key
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRetryable reports whether err is a Momo API error for a transient failure that may succeed if retried
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRetryable)
+}
@@ -0,0 +1,86 @@
+package gomomo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := []struct {
+		name         string
+		res          *Response
+		notFound     bool
+		conflict     bool
+		unauthorized bool
+		retryable    bool
+	}{
+		{
+			name:     "duplicate reference ID",
+			res:      &Response{StatusCode: http.StatusConflict, Body: []byte(`{"code":"RESOURCE_ALREADY_EXIST","message":"Duplicated reference id."}`)},
+			conflict: true,
+		},
+		{
+			name:     "payer not found",
+			res:      &Response{StatusCode: http.StatusInternalServerError, Body: []byte(`{"code":"PAYER_NOT_FOUND","message":"Payer not found"}`)},
+			notFound: true,
+			// A 500 is retryable regardless of the code in the body
+			retryable: true,
+		},
+		{
+			name:         "invalid token",
+			res:          &Response{StatusCode: http.StatusUnauthorized, Body: []byte(`{"statusCode": 401, "message": "Access denied due to invalid subscription key."}`)},
+			unauthorized: true,
+		},
+		{
+			name:      "throttled",
+			res:       &Response{StatusCode: http.StatusTooManyRequests},
+			retryable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		err := newErrorResponse(tt.res)
+		if IsNotFound(err) != tt.notFound {
+			t.Errorf("%s: IsNotFound = %v, expected %v", tt.name, IsNotFound(err), tt.notFound)
+		}
+		if IsConflict(err) != tt.conflict {
+			t.Errorf("%s: IsConflict = %v, expected %v", tt.name, IsConflict(err), tt.conflict)
+		}
+		if IsUnauthorized(err) != tt.unauthorized {
+			t.Errorf("%s: IsUnauthorized = %v, expected %v", tt.name, IsUnauthorized(err), tt.unauthorized)
+		}
+		if IsRetryable(err) != tt.retryable {
+			t.Errorf("%s: IsRetryable = %v, expected %v", tt.name, IsRetryable(err), tt.retryable)
+		}
+	}
+}
+
+func TestErrorResponse_FromService(t *testing.T) {
+	setup()
+	defer teardown()
+	transactionID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	mux.HandleFunc(fmt.Sprintf("%s/%s", collectionsRequestToPayURL, transactionID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"RESOURCE_NOT_FOUND","message":"Requested resource was not found."}`)
+	})
+
+	_, err := client.Collection.GetTransaction(ctx, transactionID)
+	var errResponse *ErrorResponse
+	if !errors.As(err, &errResponse) {
+		t.Fatalf("Expected an *ErrorResponse but got %T", err)
+	}
+	if errResponse.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status code 404 but got %d", errResponse.StatusCode)
+	}
+	if errResponse.Code != ErrorCodeResourceNotFound {
+		t.Errorf("Expected code %s but got %s", ErrorCodeResourceNotFound, errResponse.Code)
+	}
+	if errResponse.ReferenceID == "" {
+		t.Errorf("Expected the reference ID of the request to be set")
+	}
+	if !IsNotFound(err) {
+		t.Errorf("Expected IsNotFound to be true")
+	}
+}
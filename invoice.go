@@ -0,0 +1,251 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	collectionsInvoiceURL = "/collection/v2_0/invoice"
+	collectionsPaymentURL = "/collection/v2_0/payment"
+)
+
+// InvoiceRequest holds the details of an invoice that a customer pays by quoting its payment reference
+type InvoiceRequest struct {
+	ExternalID string `json:"externalId"`
+	Amount     Amount `json:"amount"`
+	Currency   string `json:"currency"`
+	// Validity is how long the invoice can be paid for. It is sent in whole seconds.
+	Validity      time.Duration `json:"-"`
+	IntendedPayer Party         `json:"intendedPayer"`
+	Payee         Party         `json:"payee"`
+	Description   string        `json:"description,omitempty"`
+
+	// CallbackURL is where Momo sends the final status of the invoice, sent as X-Callback-Url
+	CallbackURL string `json:"-"`
+	// ReferenceID, when set, is used as the X-Reference-Id of the request. See WithReferenceID.
+	ReferenceID string `json:"-"`
+}
+
+// Validate checks that the invoice has an external ID, a valid amount, both parties and a validity of at least a second
+func (r *InvoiceRequest) Validate() error {
+	if r.ExternalID == "" {
+		return errors.New("externalId is required")
+	}
+	if err := validateAmount(r.Amount, r.Currency); err != nil {
+		return err
+	}
+	if err := r.IntendedPayer.Validate(); err != nil {
+		return fmt.Errorf("intendedPayer: %v", err)
+	}
+	if err := r.Payee.Validate(); err != nil {
+		return fmt.Errorf("payee: %v", err)
+	}
+	if r.Validity < time.Second {
+		return errors.New("validity must be at least one second")
+	}
+	return nil
+}
+
+// MarshalJSON encodes the request with its Validity as the validityDuration in seconds expected by Momo
+func (r InvoiceRequest) MarshalJSON() ([]byte, error) {
+	type invoiceRequest InvoiceRequest
+	return json.Marshal(struct {
+		invoiceRequest
+		ValidityDuration string `json:"validityDuration"`
+	}{
+		invoiceRequest:   invoiceRequest(r),
+		ValidityDuration: fmt.Sprint(int64(r.Validity / time.Second)),
+	})
+}
+
+// InvoiceStatusResponse holds the status of an invoice created with CreateInvoice
+type InvoiceStatusResponse struct {
+	ReferenceID      string            `json:"referenceId"`
+	ExternalID       string            `json:"externalId"`
+	Amount           Amount            `json:"amount"`
+	Currency         string            `json:"currency"`
+	Status           TransactionStatus `json:"status"`
+	PaymentReference string            `json:"paymentReference"`
+	InvoiceID        string            `json:"invoiceId"`
+	ExpiryDateTime   string            `json:"expiryDateTime,omitempty"`
+	PayeeFirstName   string            `json:"payeeFirstName,omitempty"`
+	PayeeLastName    string            `json:"payeeLastName,omitempty"`
+	IntendedPayer    Party             `json:"intendedPayer"`
+	Description      string            `json:"description,omitempty"`
+	ErrorReason      *ErrorReason      `json:"errorReason,omitempty"`
+}
+
+// PaymentRequest holds the details of a payment made towards a customer reference, such as a bill or an invoice
+type PaymentRequest struct {
+	ExternalTransactionID   string `json:"externalTransactionId"`
+	Money                   Money  `json:"money"`
+	CustomerReference       string `json:"customerReference"`
+	ServiceProviderUserName string `json:"serviceProviderUserName"`
+	CouponID                string `json:"couponId,omitempty"`
+	ProductID               string `json:"productId,omitempty"`
+	ProductOfferingID       string `json:"productOfferingId,omitempty"`
+	ReceiverMessage         string `json:"receiverMessage,omitempty"`
+	SenderNote              string `json:"senderNote,omitempty"`
+	MaxNumberOfRetries      int    `json:"maxNumberOfRetries,omitempty"`
+	IncludeSenderCharges    bool   `json:"includeSenderCharges"`
+
+	// CallbackURL is where Momo sends the final status of the payment, sent as X-Callback-Url
+	CallbackURL string `json:"-"`
+	// ReferenceID, when set, is used as the X-Reference-Id of the request. See WithReferenceID.
+	ReferenceID string `json:"-"`
+}
+
+// Validate checks that the payment has an external transaction ID, a customer reference,
+// a service provider and a valid amount
+func (r *PaymentRequest) Validate() error {
+	if r.ExternalTransactionID == "" {
+		return errors.New("externalTransactionId is required")
+	}
+	if r.CustomerReference == "" {
+		return errors.New("customerReference is required")
+	}
+	if r.ServiceProviderUserName == "" {
+		return errors.New("serviceProviderUserName is required")
+	}
+	return r.Money.Validate()
+}
+
+// PaymentResult holds the status of a payment created with CreatePayment
+type PaymentResult struct {
+	ReferenceID            string            `json:"referenceId"`
+	Status                 TransactionStatus `json:"status"`
+	FinancialTransactionID string            `json:"financialTransactionId,omitempty"`
+	Reason                 *ErrorReason      `json:"reason,omitempty"`
+}
+
+// CreateInvoice creates an invoice that the intended payer can pay using its payment reference and returns the
+// reference ID of the invoice. An invoice whose reference ID already exists is reported as a success.
+func (c *CollectionServiceOp) CreateInvoice(ctx context.Context, r *InvoiceRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationCreateInvoice)
+	defer cancel()
+
+	err := r.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	requestBody := *r
+	requestBody.Currency, err = c.client.applyCurrencyRule(r.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	return c.client.create(ctx, collectionsInvoiceURL, requestBody, r.ReferenceID, r.CallbackURL, func(ctx context.Context, referenceID string) error {
+		_, err := c.GetInvoiceStatus(ctx, referenceID)
+		return err
+	})
+}
+
+// GetInvoiceStatus retrieves the status of an invoice using the reference ID returned by CreateInvoice
+func (c *CollectionServiceOp) GetInvoiceStatus(ctx context.Context, referenceID string) (*InvoiceStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetInvoiceStatus)
+	defer cancel()
+
+	urlStr := fmt.Sprintf("%s/%s", collectionsInvoiceURL, referenceID)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	status := &InvoiceStatusResponse{}
+	err = json.Unmarshal(res.Body, status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// CancelInvoice cancels an unpaid invoice. externalID is the ExternalID the invoice was created with.
+func (c *CollectionServiceOp) CancelInvoice(ctx context.Context, referenceID, externalID string) error {
+	ctx, cancel := c.client.withTimeout(ctx, OperationCancelInvoice)
+	defer cancel()
+
+	urlStr := fmt.Sprintf("%s/%s", collectionsInvoiceURL, referenceID)
+	requestBody := struct {
+		ExternalID string `json:"externalId"`
+	}{ExternalID: externalID}
+	req, err := c.client.NewRequest(ctx, http.MethodDelete, urlStr, requestBody)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		return newErrorResponse(res)
+	}
+	return nil
+}
+
+// CreatePayment pays towards a customer reference, such as the payment reference of an invoice, and returns the
+// reference ID of the payment. A payment whose reference ID already exists is reported as a success.
+func (c *CollectionServiceOp) CreatePayment(ctx context.Context, r *PaymentRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationCreatePayment)
+	defer cancel()
+
+	err := r.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	requestBody := *r
+	requestBody.Money.Currency, err = c.client.applyCurrencyRule(r.Money.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	return c.client.create(ctx, collectionsPaymentURL, requestBody, r.ReferenceID, r.CallbackURL, func(ctx context.Context, referenceID string) error {
+		_, err := c.GetPaymentStatus(ctx, referenceID)
+		return err
+	})
+}
+
+// GetPaymentStatus retrieves the status of a payment using the reference ID returned by CreatePayment
+func (c *CollectionServiceOp) GetPaymentStatus(ctx context.Context, referenceID string) (*PaymentResult, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetPaymentStatus)
+	defer cancel()
+
+	urlStr := fmt.Sprintf("%s/%s", collectionsPaymentURL, referenceID)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	result := &PaymentResult{}
+	err = json.Unmarshal(res.Body, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
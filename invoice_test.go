@@ -0,0 +1,177 @@
+package gomomo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCollectionServiceOp_CreateInvoice(t *testing.T) {
+	t.Run("CreateInvoice returns 202_ACCEPTED", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		mux.HandleFunc(collectionsInvoiceURL, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			testHeaders(t, r, headers{"X-Callback-Url": "https://example.com/momo/invoice"})
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if body["validityDuration"] != "3600" {
+				t.Errorf("Expected a validityDuration of 3600 seconds but got %v", body["validityDuration"])
+			}
+			if body["amount"] != "100" {
+				t.Errorf("Expected amount 100 but got %v", body["amount"])
+			}
+			payer, _ := body["intendedPayer"].(map[string]interface{})
+			if payer["partyId"] != "25678999720" {
+				t.Errorf("Expected the intended payer 25678999720 but got %v", body["intendedPayer"])
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+
+		referenceID, err := client.Collection.CreateInvoice(ctx, &InvoiceRequest{
+			ExternalID:    "inv-001",
+			Amount:        NewAmount(100),
+			Currency:      "EUR",
+			Validity:      time.Hour,
+			IntendedPayer: Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+			Payee:         Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999721"},
+			Description:   "Water bill",
+			CallbackURL:   "https://example.com/momo/invoice",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if referenceID == "" {
+			t.Errorf("Expected referenceID to be a non empty string")
+		}
+	})
+
+	t.Run("CreateInvoice rejects a missing intended payer", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		_, err := client.Collection.CreateInvoice(ctx, &InvoiceRequest{
+			ExternalID: "inv-001",
+			Amount:     NewAmount(100),
+			Currency:   "EUR",
+			Validity:   time.Hour,
+			Payee:      Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999721"},
+		})
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+	})
+}
+
+func TestCollectionServiceOp_GetInvoiceStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	expectedStatus := InvoiceStatusResponse{
+		ReferenceID:      referenceID,
+		ExternalID:       "inv-001",
+		Amount:           NewAmount(100),
+		Currency:         "EUR",
+		Status:           StatusPending,
+		PaymentReference: "123456",
+		InvoiceID:        "9876",
+		ExpiryDateTime:   "2021-08-22T10:05:00.000Z",
+		IntendedPayer:    Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+	}
+
+	mux.HandleFunc(fmt.Sprintf("%s/%s", collectionsInvoiceURL, referenceID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		json.NewEncoder(w).Encode(expectedStatus)
+	})
+
+	actualStatus, err := client.Collection.GetInvoiceStatus(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !reflect.DeepEqual(actualStatus, &expectedStatus) {
+		t.Errorf("GetInvoiceStatus\n got=%#v\nwant=%#v", actualStatus, expectedStatus)
+	}
+}
+
+func TestCollectionServiceOp_CancelInvoice(t *testing.T) {
+	setup()
+	defer teardown()
+
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	mux.HandleFunc(fmt.Sprintf("%s/%s", collectionsInvoiceURL, referenceID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		body := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if body["externalId"] != "inv-001" {
+			t.Errorf("Expected externalId inv-001 but got %q", body["externalId"])
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if err := client.Collection.CancelInvoice(ctx, referenceID, "inv-001"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
+
+func TestCollectionServiceOp_CreatePayment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(collectionsPaymentURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		body := struct {
+			Money             Money  `json:"money"`
+			CustomerReference string `json:"customerReference"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if body.Money.String() != "25.50 EUR" || body.CustomerReference != "123456" {
+			t.Errorf("Unexpected payment body %+v", body)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	money, _ := NewMoney(2550, "EUR")
+	referenceID, err := client.Collection.CreatePayment(ctx, &PaymentRequest{
+		ExternalTransactionID:   "pay-001",
+		Money:                   money,
+		CustomerReference:       "123456",
+		ServiceProviderUserName: "water-utility",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if referenceID == "" {
+		t.Errorf("Expected referenceID to be a non empty string")
+	}
+}
+
+func TestCollectionServiceOp_GetPaymentStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+	mux.HandleFunc(fmt.Sprintf("%s/%s", collectionsPaymentURL, referenceID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"referenceId": %q, "status": "SUCCESSFUL", "financialTransactionId": "2312"}`, referenceID)
+	})
+
+	result, err := client.Collection.GetPaymentStatus(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	expected := &PaymentResult{ReferenceID: referenceID, Status: StatusSuccessful, FinancialTransactionID: "2312"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("GetPaymentStatus\n got=%#v\nwant=%#v", result, expected)
+	}
+}
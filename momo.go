@@ -4,15 +4,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
-	mediaType = "application/json"
+	mediaType        = "application/json"
+	formMediaType    = "application/x-www-form-urlencoded"
+	defaultUserAgent = "gomomo"
+
+	// SandboxBaseURL is the base URL of the Momo sandbox
+	SandboxBaseURL = "https://sandbox.momodeveloper.mtn.com/"
+	// ProductionBaseURL is the base URL of the Momo production API
+	ProductionBaseURL = "https://proxy.momoapi.mtn.com/"
+
+	// EnvironmentSandbox is the target environment of the Momo sandbox. Production target
+	// environments are named per country, e.g. mtnuganda.
+	EnvironmentSandbox = "sandbox"
 )
 
 // Client manages communication with MTN Momo API.
@@ -22,10 +37,31 @@ type Client struct {
 	SubscriptionKey string
 	Token           string
 	Environment     string
-	Collection      CollectionService
-	Disbursement    DisbursementService
-	Remittance      RemittanceService
-	Sandbox         SandboxService
+	UserAgent       string
+
+	// Timeouts sets the default timeout of individual operations. See SetTimeout.
+	Timeouts map[Operation]time.Duration
+	// DefaultTimeout applies to operations without an entry in Timeouts. Zero disables it.
+	DefaultTimeout time.Duration
+	// RetryPolicy decides which failed requests are retried, see DefaultRetryPolicy
+	RetryPolicy  RetryPolicy
+	Collection   CollectionService
+	Disbursement DisbursementService
+	Remittance   RemittanceService
+	Sandbox      SandboxService
+
+	// CurrencyRules maps a target environment to the currencies it accepts. See SetCurrencyRule.
+	CurrencyRules map[string]CurrencyRule
+	// CurrencyCoerced, when set, is called whenever a CurrencyRule rewrites the currency of a request
+	CurrencyCoerced func(environment, from, to string)
+
+	tokenSources map[Product]*TokenSource
+	// keysMu guards subscriptionKeys, which SetCredentials may change while requests are sent
+	keysMu           sync.RWMutex
+	subscriptionKeys map[Product]string
+	limiters         map[Product]*limiter
+	// httpTimeout is the timeout set by WithTimeout, applied once every Option has run
+	httpTimeout *time.Duration
 }
 
 // Response returned by API calls
@@ -44,90 +80,324 @@ type tokenResponse struct {
 
 // BalanceResponse holds the account Balance
 type BalanceResponse struct {
-	AvailableBalance string `json:"availableBalance"`
+	AvailableBalance Amount `json:"availableBalance"`
 	Currency         string `json:"currency"`
 }
 
-type paymentDetails struct {
-	PartyIDType string `json:"partyIdType"`
-	PartyID     string `json:"partyId"`
+// PartyIDType is the type of identifier used for a Party
+type PartyIDType string
+
+// Party ID types accepted by the Momo API
+const (
+	PartyIDTypeMSISDN    PartyIDType = "MSISDN"
+	PartyIDTypeEmail     PartyIDType = "EMAIL"
+	PartyIDTypePartyCode PartyIDType = "PARTY_CODE"
+)
+
+// Party identifies the account holder paying or receiving funds
+type Party struct {
+	PartyIDType PartyIDType `json:"partyIdType,omitempty"`
+	PartyID     string      `json:"partyId,omitempty"`
+}
+
+// Validate checks that the party has a supported ID type and a non empty ID
+func (p Party) Validate() error {
+	switch p.PartyIDType {
+	case PartyIDTypeMSISDN, PartyIDTypeEmail, PartyIDTypePartyCode:
+	default:
+		return fmt.Errorf("invalid party ID type %q", p.PartyIDType)
+	}
+	if p.PartyID == "" {
+		return errors.New("party ID is required")
+	}
+	return nil
+}
+
+// path returns the segments identifying the party in a URL path, e.g. msisdn/46733123450.
+// The party ID is escaped, as email addresses and party codes may contain reserved characters.
+func (p Party) path() string {
+	return strings.ToLower(string(p.PartyIDType)) + "/" + url.PathEscape(p.PartyID)
+}
+
+// RequestToPayRequest holds the details of a payment requested from a Payer
+type RequestToPayRequest struct {
+	Amount       Amount `json:"amount"`
+	Currency     string `json:"currency"`
+	ExternalID   string `json:"externalId"`
+	Payer        Party  `json:"payer"`
+	PayerMessage string `json:"payerMessage"`
+	PayeeNote    string `json:"payeeNote"`
+
+	// CallbackURL is where Momo sends the final status of the request, sent as X-Callback-Url
+	CallbackURL string `json:"-"`
+	// ReferenceID, when set, is used as the X-Reference-Id of the request. See WithReferenceID.
+	ReferenceID string `json:"-"`
+}
+
+// Validate checks that the request has a payer and a positive amount in a known currency
+func (r *RequestToPayRequest) Validate() error {
+	if err := r.Payer.Validate(); err != nil {
+		return fmt.Errorf("payer: %v", err)
+	}
+	return validateAmount(r.Amount, r.Currency)
+}
+
+// TransferRequest holds the details of funds transferred to a Payee
+type TransferRequest struct {
+	Amount       Amount `json:"amount"`
+	Currency     string `json:"currency"`
+	ExternalID   string `json:"externalId"`
+	Payee        Party  `json:"payee"`
+	PayerMessage string `json:"payerMessage"`
+	PayeeNote    string `json:"payeeNote"`
+
+	// CallbackURL is where Momo sends the final status of the transfer, sent as X-Callback-Url
+	CallbackURL string `json:"-"`
+	// ReferenceID, when set, is used as the X-Reference-Id of the request. See WithReferenceID.
+	ReferenceID string `json:"-"`
 }
 
-type paymentRequestBody struct {
-	Amount       int64          `json:"amount"`
-	Currency     string         `json:"currency"`
-	ExternalID   string         `json:"externalId"`
-	Payer        paymentDetails `json:"payer"`
-	PayerMessage string         `json:"payerMessage"`
-	PayeeNote    string         `json:"payeeNote"`
+// Validate checks that the request has a payee and a positive amount in a known currency
+func (r *TransferRequest) Validate() error {
+	if err := r.Payee.Validate(); err != nil {
+		return fmt.Errorf("payee: %v", err)
+	}
+	return validateAmount(r.Amount, r.Currency)
 }
 
-type transferRequestBody struct {
-	Amount       int64          `json:"amount"`
-	Currency     string         `json:"currency"`
-	ExternalID   string         `json:"externalId"`
-	Payee        paymentDetails `json:"payee"`
-	PayerMessage string         `json:"payerMessage"`
-	PayeeNote    string         `json:"payeeNote"`
+// RefundRequest holds the details of a refund of a payment collected with RequestToPay
+type RefundRequest struct {
+	Amount       Amount `json:"amount"`
+	Currency     string `json:"currency"`
+	ExternalID   string `json:"externalId"`
+	PayerMessage string `json:"payerMessage"`
+	PayeeNote    string `json:"payeeNote"`
+	// ReferenceIDToRefund is the reference ID of the request to pay being refunded
+	ReferenceIDToRefund string `json:"referenceIdToRefund"`
+
+	// CallbackURL is where Momo sends the final status of the refund, sent as X-Callback-Url
+	CallbackURL string `json:"-"`
+	// ReferenceID, when set, is used as the X-Reference-Id of the request. See WithReferenceID.
+	ReferenceID string `json:"-"`
 }
 
-type reason struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+// Validate checks that the request refers to the payment being refunded and has a positive amount in a known currency
+func (r *RefundRequest) Validate() error {
+	if _, err := uuid.Parse(r.ReferenceIDToRefund); err != nil {
+		return fmt.Errorf("invalid referenceIdToRefund %q: %v", r.ReferenceIDToRefund, err)
+	}
+	return validateAmount(r.Amount, r.Currency)
 }
 
 // PaymentStatusResponse returned for every successful call to make a transfer
 type PaymentStatusResponse struct {
-	Amount                 string         `json:"amount,omitempty"`
-	Currency               string         `json:"currency,omitempty"`
-	FinancialTransactionID int64          `json:"financialTransactionId,omitempty"`
-	ExternalID             string         `json:"externalId,omitempty"`
-	Payer                  paymentDetails `json:"paymentDetails,omitempty"`
-	Status                 string         `json:"status,omitempty"`
-	Reason                 string         `json:"reason,omitempty"`
+	Amount                 Amount            `json:"amount"`
+	Currency               string            `json:"currency,omitempty"`
+	FinancialTransactionID string            `json:"financialTransactionId,omitempty"`
+	ExternalID             string            `json:"externalId,omitempty"`
+	Payer                  Party             `json:"payer,omitempty"`
+	Payee                  Party             `json:"payee,omitempty"`
+	PayerMessage           string            `json:"payerMessage,omitempty"`
+	PayeeNote              string            `json:"payeeNote,omitempty"`
+	Status                 TransactionStatus `json:"status,omitempty"`
+	Reason                 string            `json:"reason,omitempty"`
 }
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
-// BaseURL of the Client.
+// BaseURL of the Client. Requests to a product with credentials set on its TokenSource are authorized with
+// a token from that source, otherwise the Client's Token is used. A url.Values body is sent form-encoded,
+// any other body is encoded as JSON.
 func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
+	req, err := c.newRequest(ctx, method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if ts := c.tokenSourceFor(req); ts != nil && ts.hasCredentials() {
+		token, err := ts.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if c.Token != "" {
+		req.Header.Add("Authorization", "Bearer "+c.Token)
+	}
+
+	return req, nil
+}
+
+// newRequest creates an unauthorized API request
+func (c *Client) newRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	u, err := c.BaseURL.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
 
+	contentType := mediaType
 	buf := new(bytes.Buffer)
-	if body != nil {
+	if form, ok := body.(url.Values); ok {
+		contentType = formMediaType
+		buf.WriteString(form.Encode())
+	} else if body != nil {
 		err = json.NewEncoder(buf).Encode(body)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
 
-	req.WithContext(ctx)
-
-	req.Header.Add("Content-Type", mediaType)
-	req.Header.Add("X-Reference-Id", uuid.New().String())
-	req.Header.Add("Ocp-Apim-Subscription-Key", c.SubscriptionKey)
+	req.Header.Add("Content-Type", contentType)
+	referenceID, err := referenceIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("X-Reference-Id", referenceID)
+	req.Header.Add("Ocp-Apim-Subscription-Key", c.subscriptionKey(u))
 
 	if c.Environment != "" {
 		req.Header.Add("X-Target-Environment", c.Environment)
 	}
-	if c.Token != "" {
-		req.Header.Add("Authorization", "Bearer "+c.Token)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
 	}
 
 	return req, nil
 }
 
-// Do sends an API request and returns the API response.
+type contextKey int
+
+const (
+	referenceIDKey contextKey = iota
+	retryPolicyKey
+	userTokenKey
+)
+
+// NewReferenceID returns a new reference ID which can be persisted before a request is sent
+// and passed to WithReferenceID so that the request can be resent safely.
+func NewReferenceID() string {
+	return uuid.New().String()
+}
+
+// WithReferenceID returns a copy of ctx which makes requests created with it use referenceID as
+// their X-Reference-Id instead of a freshly generated one. Resending RequestToPay or Transfer with
+// the same reference ID never creates a second transaction.
+func WithReferenceID(ctx context.Context, referenceID string) context.Context {
+	return context.WithValue(ctx, referenceIDKey, referenceID)
+}
+
+func referenceIDFromContext(ctx context.Context) (string, error) {
+	referenceID, ok := ctx.Value(referenceIDKey).(string)
+	if !ok || referenceID == "" {
+		return NewReferenceID(), nil
+	}
+	if _, err := uuid.Parse(referenceID); err != nil {
+		return "", fmt.Errorf("invalid reference ID %q: %v", referenceID, err)
+	}
+	return referenceID, nil
+}
+
+// withReferenceID applies the reference ID of a request struct to ctx, leaving ctx untouched when it is empty
+func withReferenceID(ctx context.Context, referenceID string) context.Context {
+	if referenceID == "" {
+		return ctx
+	}
+	return WithReferenceID(ctx, referenceID)
+}
+
+// TokenSource returns the TokenSource used to authorize requests to the given product
+func (c *Client) TokenSource(p Product) *TokenSource {
+	return c.tokenSources[p]
+}
+
+func (c *Client) tokenSourceFor(req *http.Request) *TokenSource {
+	return c.tokenSources[productFromPath(req.URL.Path)]
+}
+
+// Do sends an API request and returns the API response. Requests failing with a transient error are
+// retried according to the Client's RetryPolicy, or the one set on ctx with WithRetryPolicy.
+// A request authorized by a TokenSource that is rejected with 401 Unauthorized is sent once more
+// with a freshly fetched token.
 func (c *Client) Do(ctx context.Context, req *http.Request) (*Response, error) {
-	req.WithContext(ctx)
-	res, err := c.client.Do(req)
+	policy := c.retryPolicy(ctx)
+	attempts := policy.attempts(ctx, req)
+
+	for attempt := 1; ; attempt++ {
+		response, err := c.doAuthorized(ctx, req)
+		if attempt >= attempts || !shouldRetry(ctx, response, err) {
+			return response, err
+		}
+
+		err = sleep(ctx, policy.delay(attempt-1, response))
+		if err != nil {
+			return nil, err
+		}
+		req, err = rewindRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) doAuthorized(ctx context.Context, req *http.Request) (*Response, error) {
+	response, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusUnauthorized {
+		return response, nil
+	}
+
+	if ctx.Value(userTokenKey) != nil {
+		// The request is authorized by a consumer's token, which the product's TokenSource cannot refresh
+		return response, nil
+	}
+	ts := c.tokenSourceFor(req)
+	auth := req.Header.Get("Authorization")
+	// Token requests use basic auth and are never retried here, as their TokenSource is locked while they run
+	if !strings.HasPrefix(auth, "Bearer ") || ts == nil || !ts.hasCredentials() || req.GetBody == nil {
+		return response, nil
+	}
+	ts.invalidate(strings.TrimPrefix(auth, "Bearer "))
+	token, err := ts.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	retry, err := rewindRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+	return c.do(ctx, retry)
+}
+
+// rewindRequest returns a copy of req, with the same headers and a fresh body, that can be sent again
+func rewindRequest(ctx context.Context, req *http.Request) (*http.Request, error) {
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request) (*Response, error) {
+	if l, ok := c.limiters[productFromPath(req.URL.Path)]; ok {
+		release, err := l.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	res, err := c.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -151,23 +421,86 @@ func buildResponse(res *http.Response) (*Response, error) {
 	return &response, err
 }
 
-// NewClient returns a new Momo API client, using the given
-// http.Client to perform all requests.
-func NewClient(key, environment, baseURL string) *Client {
-	urlStr, err := url.Parse(baseURL)
+// create posts body to urlStr and returns the reference ID of the resource it creates. referenceID,
+// or failing that the one set on ctx with WithReferenceID, is sent as the X-Reference-Id. A 409 Conflict
+// means the reference ID was already used, which happens when a request is resent; it is reported as a
+// success as long as lookup finds the resource.
+func (c *Client) create(ctx context.Context, urlStr string, body interface{}, referenceID, callbackURL string, lookup func(ctx context.Context, referenceID string) error) (string, error) {
+	// The request is sent with the reference ID on its context, so that it may be retried
+	reqCtx := withReferenceID(ctx, referenceID)
+	req, err := c.NewRequest(reqCtx, http.MethodPost, urlStr, body)
+	if err != nil {
+		return "", err
+	}
+	if callbackURL != "" {
+		req.Header.Set("X-Callback-Url", callbackURL)
+	}
+	referenceID = req.Header.Get("X-Reference-Id")
+
+	res, err := c.Do(reqCtx, req)
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode == http.StatusConflict {
+		err = lookup(ctx, referenceID)
+		if err != nil {
+			return "", err
+		}
+		return referenceID, nil
+	}
+
+	if res.StatusCode != http.StatusAccepted {
+		return "", newErrorResponse(res)
+	}
+
+	return referenceID, nil
+}
+
+// paymentLookup adapts a lookup of a payment or transfer status to the lookup taken by create
+func paymentLookup(get func(ctx context.Context, referenceID string) (*PaymentStatusResponse, error)) func(ctx context.Context, referenceID string) error {
+	return func(ctx context.Context, referenceID string) error {
+		_, err := get(ctx, referenceID)
+		return err
+	}
+}
+
+// NewClient returns a new Momo API client configured by opts. Without options the client talks to
+// the sandbox using http.DefaultClient.
+func NewClient(opts ...Option) (*Client, error) {
+	baseURL, err := url.Parse(SandboxBaseURL)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	c := &Client{
-		client:          http.DefaultClient,
-		BaseURL:         urlStr,
-		SubscriptionKey: key,
-		Token:           "",
-		Environment:     environment,
+		client:         http.DefaultClient,
+		BaseURL:        baseURL,
+		Environment:    EnvironmentSandbox,
+		UserAgent:      defaultUserAgent,
+		Timeouts:       defaultTimeouts(),
+		DefaultTimeout: DefaultTimeout,
+		RetryPolicy:    DefaultRetryPolicy,
+	}
+	c.subscriptionKeys = map[Product]string{}
+	c.tokenSources = map[Product]*TokenSource{
+		ProductCollection:   newTokenSource(c, collectionsTokenURL),
+		ProductDisbursement: newTokenSource(c, disbursementsTokenURL),
+		ProductRemittance:   newTokenSource(c, remittancesTokenURL),
 	}
 	c.Collection = &CollectionServiceOp{client: c}
 	c.Disbursement = &DisbursementServiceOp{client: c}
 	c.Remittance = &RemittanceServiceOp{client: c}
 	c.Sandbox = &SandboxServiceOp{client: c}
-	return c
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.httpTimeout != nil {
+		httpClient := *c.client
+		httpClient.Timeout = *c.httpTimeout
+		c.client = &httpClient
+	}
+	return c, nil
 }
@@ -4,8 +4,8 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"testing"
+	"time"
 )
 
 var (
@@ -22,9 +22,9 @@ func setup() {
 	mux = http.NewServeMux()
 	server = httptest.NewServer(mux)
 
-	client = NewClient("", "sandbox", server.URL)
-	urlStr, _ := url.Parse(server.URL)
-	client.BaseURL = urlStr
+	client, _ = NewClient(WithEnvironment("sandbox"), WithBaseURL(server.URL))
+	client.RetryPolicy.InitialBackoff = time.Millisecond
+	client.RetryPolicy.MaxBackoff = time.Millisecond
 }
 
 func teardown() {
@@ -0,0 +1,78 @@
+package momotest
+
+import (
+	"net/http"
+
+	"github.com/phillipahereza/gomomo"
+)
+
+// Outcome scripts how the fake server answers a request to pay or transfer
+type Outcome struct {
+	// Status is the status the transaction is created with. A transaction that is not final
+	// stays in that status until Server.Complete is called.
+	Status gomomo.TransactionStatus
+	// Reason is reported with FAILED, REJECTED and TIMEOUT transactions
+	Reason string
+
+	// HTTPStatus, when set, rejects the request itself with this status and no transaction is created
+	HTTPStatus int
+	// ErrorCode and ErrorMessage are sent in the body of a rejected request
+	ErrorCode    string
+	ErrorMessage string
+}
+
+// Succeed completes the transaction successfully. It is the default outcome.
+func Succeed() Outcome {
+	return Outcome{Status: gomomo.StatusSuccessful}
+}
+
+// Fail completes the transaction as FAILED with the given reason, e.g. gomomo.ErrorCodePayerNotFound
+func Fail(reason string) Outcome {
+	return Outcome{Status: gomomo.StatusFailed, Reason: reason}
+}
+
+// Reject completes the transaction as REJECTED, as if the payer declined it
+func Reject() Outcome {
+	return Outcome{Status: gomomo.StatusRejected, Reason: "APPROVAL_REJECTED"}
+}
+
+// Timeout completes the transaction as TIMEOUT, as if the payer never answered
+func Timeout() Outcome {
+	return Outcome{Status: gomomo.StatusTimeout, Reason: "EXPIRED"}
+}
+
+// Pending leaves the transaction PENDING until Server.Complete is called
+func Pending() Outcome {
+	return Outcome{Status: gomomo.StatusPending}
+}
+
+// Ongoing leaves the transaction ONGOING until Server.Complete is called
+func Ongoing() Outcome {
+	return Outcome{Status: gomomo.StatusOngoing}
+}
+
+// Error rejects the request with an HTTP status and a Momo error code, e.g.
+// Error(http.StatusInternalServerError, gomomo.ErrorCodeInternalProcessingError, "")
+func Error(httpStatus int, code, message string) Outcome {
+	if message == "" {
+		message = http.StatusText(httpStatus)
+	}
+	return Outcome{HTTPStatus: httpStatus, ErrorCode: code, ErrorMessage: message}
+}
+
+// sandboxOutcome returns the outcome the Momo sandbox gives transactions completing with status
+func sandboxOutcome(status gomomo.TransactionStatus) Outcome {
+	switch status {
+	case gomomo.StatusFailed:
+		return Fail(gomomo.ErrorCodeInternalProcessingError)
+	case gomomo.StatusRejected:
+		return Reject()
+	case gomomo.StatusTimeout:
+		return Timeout()
+	case gomomo.StatusOngoing:
+		return Ongoing()
+	case gomomo.StatusPending:
+		return Pending()
+	}
+	return Succeed()
+}
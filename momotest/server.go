@@ -0,0 +1,387 @@
+// Package momotest provides an in-process fake of the Momo API for testing code that uses gomomo
+// without network access. The fake issues tokens, accepts requests to pay, disbursement transfers and
+// remittance transfers, keeps their state in memory and sends callbacks once they complete.
+package momotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phillipahereza/gomomo"
+)
+
+// Credentials of the API user every product of a Server accepts
+const (
+	UserID = "momotest-user"
+	APIKey = "momotest-key"
+)
+
+// DefaultCallbackTimeout is the CallbackTimeout of a new Server
+const DefaultCallbackTimeout = 5 * time.Second
+
+const (
+	requestToPayPath         = "/collection/v1_0/requesttopay"
+	disbursementTransferPath = "/disbursement/v1_0/transfer"
+	remittanceTransferPath   = "/remittance/v1_0/transfer"
+)
+
+// Transaction is a request to pay or transfer received by a Server
+type Transaction struct {
+	Product     gomomo.Product
+	ReferenceID string
+	// CallbackURL is where the final status of the transaction is sent, if anywhere
+	CallbackURL string
+	Payment     gomomo.PaymentStatusResponse
+}
+
+// Server is a fake Momo API. Create one with NewServer and point a gomomo.Client at it with Client.
+type Server struct {
+	// URL is the base URL of the server
+	URL string
+	// CallbackURL receives the callbacks of transactions created without an X-Callback-Url
+	CallbackURL string
+	// CallbackTimeout bounds every callback sent by the server. It starts as DefaultCallbackTimeout.
+	CallbackTimeout time.Duration
+
+	server *httptest.Server
+
+	mu                  sync.Mutex
+	users               map[string]string
+	tokens              map[string]gomomo.Product
+	transactions        map[string]*Transaction
+	outcomesByParty     map[string]Outcome
+	outcomesByReference map[string]Outcome
+	defaultOutcome      Outcome
+	nextTransactionID   int64
+	closed              bool
+
+	// pendingCallbacks counts the callbacks in flight, callbacksDone is signalled when it drops to 0
+	pendingCallbacks int
+	callbacksDone    *sync.Cond
+}
+
+// NewServer starts a fake Momo API. It must be closed with Close. Like the Momo sandbox, it completes
+// transactions with the MSISDNs of gomomo.SandboxScenarios with their status; SetOutcome overrides them.
+func NewServer() *Server {
+	s := &Server{
+		users:               map[string]string{UserID: APIKey},
+		tokens:              map[string]gomomo.Product{},
+		transactions:        map[string]*Transaction{},
+		outcomesByParty:     map[string]Outcome{},
+		outcomesByReference: map[string]Outcome{},
+		defaultOutcome:      Succeed(),
+		nextTransactionID:   1000000,
+		CallbackTimeout:     DefaultCallbackTimeout,
+	}
+	s.callbacksDone = sync.NewCond(&s.mu)
+
+	for _, scenario := range gomomo.SandboxScenarios {
+		if scenario.Status != gomomo.StatusSuccessful {
+			s.outcomesByParty[scenario.MSISDN] = sandboxOutcome(scenario.Status)
+		}
+	}
+
+	mux := http.NewServeMux()
+	for _, p := range []gomomo.Product{gomomo.ProductCollection, gomomo.ProductDisbursement, gomomo.ProductRemittance} {
+		mux.HandleFunc(fmt.Sprintf("/%s/token/", p), s.handleToken(p))
+	}
+	mux.HandleFunc(requestToPayPath, s.authorized(s.handleCreate(gomomo.ProductCollection)))
+	mux.HandleFunc(requestToPayPath+"/", s.authorized(s.handleGet(gomomo.ProductCollection, requestToPayPath)))
+	mux.HandleFunc(disbursementTransferPath, s.authorized(s.handleCreate(gomomo.ProductDisbursement)))
+	mux.HandleFunc(disbursementTransferPath+"/", s.authorized(s.handleGet(gomomo.ProductDisbursement, disbursementTransferPath)))
+	mux.HandleFunc(remittanceTransferPath, s.authorized(s.handleCreate(gomomo.ProductRemittance)))
+	mux.HandleFunc(remittanceTransferPath+"/", s.authorized(s.handleGet(gomomo.ProductRemittance, remittanceTransferPath)))
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down and waits for outstanding callbacks. Transactions completed afterwards
+// send no callback.
+func (s *Server) Close() {
+	s.server.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.waitForCallbacks()
+}
+
+// Client returns a gomomo.Client using the server with the credentials of UserID for every product.
+// opts are applied after the server's own options.
+func (s *Server) Client(opts ...gomomo.Option) (*gomomo.Client, error) {
+	creds := gomomo.Credentials{SubscriptionKey: "momotest", UserID: UserID, APIKey: APIKey}
+	return gomomo.NewClient(append([]gomomo.Option{
+		gomomo.WithBaseURL(s.URL),
+		gomomo.WithEnvironment(gomomo.EnvironmentSandbox),
+		gomomo.WithCredentials(gomomo.ProductCollection, creds),
+		gomomo.WithCredentials(gomomo.ProductDisbursement, creds),
+		gomomo.WithCredentials(gomomo.ProductRemittance, creds),
+	}, opts...)...)
+}
+
+// AddUser adds an API user accepted by every product
+func (s *Server) AddUser(userID, apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[userID] = apiKey
+}
+
+// SetOutcome scripts the outcome of transactions whose payer (for requests to pay) or payee
+// (for transfers) has the given party ID, e.g. an MSISDN
+func (s *Server) SetOutcome(partyID string, o Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outcomesByParty[partyID] = o
+}
+
+// SetOutcomeForReference scripts the outcome of the transaction with the given reference ID.
+// It takes precedence over an outcome set with SetOutcome.
+func (s *Server) SetOutcomeForReference(referenceID string, o Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outcomesByReference[referenceID] = o
+}
+
+// SetDefaultOutcome sets the outcome of transactions without a scripted outcome. It starts as Succeed.
+func (s *Server) SetDefaultOutcome(o Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultOutcome = o
+}
+
+// Transaction returns a copy of the transaction with the given reference ID
+func (s *Server) Transaction(referenceID string) (Transaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, ok := s.transactions[referenceID]
+	if !ok {
+		return Transaction{}, false
+	}
+	return *tx, true
+}
+
+// Transactions returns a copy of every transaction received by the server
+func (s *Server) Transactions() []Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	transactions := make([]Transaction, 0, len(s.transactions))
+	for _, tx := range s.transactions {
+		transactions = append(transactions, *tx)
+	}
+	return transactions
+}
+
+// Complete moves a pending or ongoing transaction to the status of o and sends its callback
+func (s *Server) Complete(referenceID string, o Outcome) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, ok := s.transactions[referenceID]
+	if !ok {
+		return fmt.Errorf("momotest: unknown transaction %s", referenceID)
+	}
+	if tx.Payment.Status.IsFinal() {
+		return fmt.Errorf("momotest: transaction %s is already %s", referenceID, tx.Payment.Status)
+	}
+	s.apply(tx, o)
+	return nil
+}
+
+// WaitForCallbacks blocks until every callback sent so far has been answered or has timed out
+func (s *Server) WaitForCallbacks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.waitForCallbacks()
+}
+
+// waitForCallbacks blocks until no callback is in flight. s.mu must be held.
+func (s *Server) waitForCallbacks() {
+	for s.pendingCallbacks > 0 {
+		s.callbacksDone.Wait()
+	}
+}
+
+// apply sets the status of tx from o and sends the callback of a final status. s.mu must be held.
+func (s *Server) apply(tx *Transaction, o Outcome) {
+	tx.Payment.Status = o.Status
+	tx.Payment.Reason = o.Reason
+	if o.Status == gomomo.StatusSuccessful {
+		s.nextTransactionID++
+		tx.Payment.FinancialTransactionID = fmt.Sprint(s.nextTransactionID)
+	}
+	if o.Status.IsFinal() && tx.CallbackURL != "" && !s.closed {
+		s.sendCallback(*tx)
+	}
+}
+
+// sendCallback sends the status of tx to its callback URL in the background. s.mu must be held.
+func (s *Server) sendCallback(tx Transaction) {
+	s.pendingCallbacks++
+	client := &http.Client{Timeout: s.CallbackTimeout}
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.pendingCallbacks--
+			if s.pendingCallbacks == 0 {
+				s.callbacksDone.Broadcast()
+			}
+		}()
+		body, err := json.Marshal(tx.Payment)
+		if err != nil {
+			return
+		}
+		req, err := http.NewRequest(http.MethodPut, tx.CallbackURL, bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Reference-Id", tx.ReferenceID)
+		res, err := client.Do(req)
+		if err != nil {
+			return
+		}
+		res.Body.Close()
+	}()
+}
+
+// outcome returns the scripted outcome of a transaction. s.mu must be held.
+func (s *Server) outcome(referenceID, partyID string) Outcome {
+	if o, ok := s.outcomesByReference[referenceID]; ok {
+		return o
+	}
+	if o, ok := s.outcomesByParty[partyID]; ok {
+		return o
+	}
+	return s.defaultOutcome
+}
+
+func (s *Server) handleToken(p gomomo.Product) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, gomomo.ErrorCodeNotAllowed, "method not allowed")
+			return
+		}
+		userID, apiKey, ok := r.BasicAuth()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !ok || s.users[userID] != apiKey || apiKey == "" {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid API user credentials")
+			return
+		}
+		token := uuid.New().String()
+		s.tokens[token] = p
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": token,
+			"token_type":   "access_token",
+			"expires_in":   3600,
+		})
+	}
+}
+
+// authorized rejects requests without a token issued for the product in their path
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		product := gomomo.Product(strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0])
+
+		s.mu.Lock()
+		p, ok := s.tokens[token]
+		s.mu.Unlock()
+		if !ok || p != product {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "access denied due to an invalid token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleCreate(p gomomo.Product) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, gomomo.ErrorCodeNotAllowed, "method not allowed")
+			return
+		}
+		referenceID := r.Header.Get("X-Reference-Id")
+		if _, err := uuid.Parse(referenceID); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REFERENCE_ID", "X-Reference-Id must be a UUID")
+			return
+		}
+		payment := gomomo.PaymentStatusResponse{}
+		if err := json.NewDecoder(r.Body).Decode(&payment); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_BODY", err.Error())
+			return
+		}
+		party := payment.Payee
+		if p == gomomo.ProductCollection {
+			party = payment.Payer
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.transactions[referenceID]; ok {
+			writeError(w, http.StatusConflict, gomomo.ErrorCodeResourceAlreadyExist, "duplicated reference id, creation of resource failed")
+			return
+		}
+		o := s.outcome(referenceID, party.PartyID)
+		if o.HTTPStatus != 0 {
+			writeError(w, o.HTTPStatus, o.ErrorCode, o.ErrorMessage)
+			return
+		}
+
+		tx := &Transaction{
+			Product:     p,
+			ReferenceID: referenceID,
+			CallbackURL: r.Header.Get("X-Callback-Url"),
+			Payment:     payment,
+		}
+		if tx.CallbackURL == "" {
+			tx.CallbackURL = s.CallbackURL
+		}
+		s.transactions[referenceID] = tx
+		s.apply(tx, o)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (s *Server) handleGet(p gomomo.Product, path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, gomomo.ErrorCodeNotAllowed, "method not allowed")
+			return
+		}
+		referenceID := strings.TrimPrefix(r.URL.Path, path+"/")
+
+		s.mu.Lock()
+		tx, ok := s.transactions[referenceID]
+		var payment gomomo.PaymentStatusResponse
+		if ok {
+			payment = tx.Payment
+		}
+		s.mu.Unlock()
+		if !ok || tx.Product != p {
+			writeError(w, http.StatusNotFound, gomomo.ErrorCodeResourceNotFound, "requested resource was not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, payment)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
@@ -0,0 +1,237 @@
+package momotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/phillipahereza/gomomo"
+)
+
+var ctx = context.Background()
+
+func newClient(t *testing.T, s *Server) *gomomo.Client {
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	return client
+}
+
+func requestToPay(payer string) *gomomo.RequestToPayRequest {
+	return &gomomo.RequestToPayRequest{
+		Amount:     gomomo.NewAmount(500),
+		Currency:   "EUR",
+		ExternalID: "34232",
+		Payer:      gomomo.Party{PartyIDType: gomomo.PartyIDTypeMSISDN, PartyID: payer},
+	}
+}
+
+func TestServer_RequestToPay(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	referenceID, err := client.Collection.CreateRequestToPay(ctx, requestToPay("256789997290"))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	status, err := client.Collection.GetTransaction(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if status.Status != gomomo.StatusSuccessful || status.FinancialTransactionID == "" || status.Payer.PartyID != "256789997290" {
+		t.Errorf("Unexpected status %#v", status)
+	}
+
+	tx, ok := s.Transaction(referenceID)
+	if !ok || tx.Product != gomomo.ProductCollection {
+		t.Errorf("Expected a collection transaction but got %#v", tx)
+	}
+
+	if _, err := client.Disbursement.GetTransfer(ctx, referenceID); !gomomo.IsNotFound(err) {
+		t.Errorf("Expected a request to pay not to be found as a transfer but got %v", err)
+	}
+}
+
+func TestServer_ScriptedOutcomes(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	s.SetOutcome("256789997291", Fail(gomomo.ErrorCodePayeeNotFound))
+	s.SetOutcome("256789997292", Error(http.StatusServiceUnavailable, gomomo.ErrorCodeServiceUnavailable, ""))
+
+	referenceID, err := client.Disbursement.Transfer(ctx, "256789997291", 500, "2323", "", "", "EUR")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	status, err := client.Disbursement.GetTransfer(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if status.Status != gomomo.StatusFailed || status.Reason != gomomo.ErrorCodePayeeNotFound {
+		t.Errorf("Unexpected status %#v", status)
+	}
+
+	_, err = client.Remittance.Transfer(ctx, "256789997292", 500, "2323", "", "", "EUR")
+	errResp := &gomomo.ErrorResponse{}
+	if !errors.As(err, &errResp) || errResp.Code != gomomo.ErrorCodeServiceUnavailable || !gomomo.IsRetryable(err) {
+		t.Errorf("Expected a retryable SERVICE_UNAVAILABLE error but got %v", err)
+	}
+	if len(s.Transactions()) != 1 {
+		t.Errorf("Expected a rejected request not to create a transaction")
+	}
+
+	referenceID = gomomo.NewReferenceID()
+	s.SetOutcomeForReference(referenceID, Reject())
+	r := requestToPay("256789997291")
+	r.ReferenceID = referenceID
+	if _, err := client.Collection.CreateRequestToPay(ctx, r); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	status, err = client.Collection.GetTransaction(ctx, referenceID)
+	if err != nil || status.Status != gomomo.StatusRejected {
+		t.Errorf("Expected a REJECTED transaction but got %#v, %v", status, err)
+	}
+}
+
+func TestServer_DuplicateReferenceID(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	r := requestToPay("256789997290")
+	r.ReferenceID = gomomo.NewReferenceID()
+	for i := 0; i < 2; i++ {
+		referenceID, err := client.Collection.CreateRequestToPay(ctx, r)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if referenceID != r.ReferenceID {
+			t.Errorf("Expected reference ID %s but got %s", r.ReferenceID, referenceID)
+		}
+	}
+	if len(s.Transactions()) != 1 {
+		t.Errorf("Expected a single transaction but got %d", len(s.Transactions()))
+	}
+}
+
+func TestServer_CompleteSendsCallback(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	completed := make(chan *gomomo.PaymentStatusResponse, 1)
+	callbacks := httptest.NewServer(&gomomo.CallbackHandler{
+		AllowedHosts: []string{"127.0.0.1", "::1"},
+		OnRequestToPayCompleted: func(ctx context.Context, referenceID string, status *gomomo.PaymentStatusResponse) error {
+			completed <- status
+			return nil
+		},
+	})
+	defer callbacks.Close()
+	s.CallbackURL = callbacks.URL + "/momo/collection"
+	s.SetDefaultOutcome(Pending())
+
+	referenceID, err := client.Collection.CreateRequestToPay(ctx, requestToPay("256789997290"))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	status, err := client.Collection.GetTransaction(ctx, referenceID)
+	if err != nil || status.Status != gomomo.StatusPending {
+		t.Fatalf("Expected a PENDING transaction but got %#v, %v", status, err)
+	}
+
+	if err := s.Complete(referenceID, Timeout()); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	select {
+	case status := <-completed:
+		if status.Status != gomomo.StatusTimeout {
+			t.Errorf("Expected a TIMEOUT callback but got %#v", status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a callback")
+	}
+
+	if err := s.Complete(referenceID, Succeed()); err == nil {
+		t.Errorf("Expected a final transaction not to be completed again")
+	}
+}
+
+func TestServer_CloseWithUnansweredCallback(t *testing.T) {
+	release := make(chan struct{})
+	callbacks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer callbacks.Close()
+	defer close(release)
+
+	s := NewServer()
+	s.CallbackTimeout = 50 * time.Millisecond
+	s.CallbackURL = callbacks.URL + "/momo/collection"
+	client := newClient(t, s)
+
+	if _, err := client.Collection.CreateRequestToPay(ctx, requestToPay("256789997290")); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Close to give up on the unanswered callback")
+	}
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := s.Client(gomomo.WithCredentials(gomomo.ProductCollection, gomomo.Credentials{UserID: UserID, APIKey: "wrong"}))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if _, err := client.Collection.GetTransaction(ctx, gomomo.NewReferenceID()); !gomomo.IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error but got %v", err)
+	}
+}
+
+func TestServer_SandboxScenarios(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newClient(t, s)
+
+	for _, scenario := range gomomo.SandboxScenarios {
+		t.Run(string(scenario.Status), func(t *testing.T) {
+			referenceID, err := client.Collection.CreateRequestToPay(ctx, requestToPay(scenario.MSISDN))
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			status, err := client.Collection.GetTransaction(ctx, referenceID)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if status.Status != scenario.Status {
+				t.Errorf("Expected %s for %s but got %s", scenario.Status, scenario.MSISDN, status.Status)
+			}
+		})
+	}
+
+	s.SetOutcome(gomomo.SandboxMSISDNFailed, Succeed())
+	referenceID, err := client.Disbursement.Transfer(ctx, gomomo.SandboxMSISDNFailed, 500, "2323", "", "", "EUR")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if tx, _ := s.Transaction(referenceID); tx.Payment.Status != gomomo.StatusSuccessful {
+		t.Errorf("Expected SetOutcome to override the sandbox scenario but got %s", tx.Payment.Status)
+	}
+}
@@ -0,0 +1,277 @@
+package gomomo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxAmountDigits is the number of digits an Amount holds without overflowing int64
+const maxAmountDigits = 18
+
+// Amount is a decimal monetary value. It marshals to and from the decimal string used by the
+// Momo API without losing precision. The zero value is an amount of 0.
+type Amount struct {
+	value int64
+	scale int
+}
+
+// NewAmount returns an amount of whole major units, e.g. NewAmount(500) is 500 UGX or 500 EUR
+func NewAmount(units int64) Amount {
+	return Amount{value: units}
+}
+
+// AmountFromMinorUnits returns the amount for a number of minor units of currency, e.g. 1050 EUR cents is 10.50
+func AmountFromMinorUnits(minor int64, currency string) (Amount, error) {
+	exponent, err := CurrencyExponent(currency)
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{value: minor, scale: exponent}, nil
+}
+
+// ParseAmount parses a decimal string such as "500" or "10.50" into an Amount
+func ParseAmount(s string) (Amount, error) {
+	digits := strings.TrimPrefix(s, "-")
+	integer, fraction := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		integer, fraction = digits[:i], digits[i+1:]
+	}
+	if integer == "" || strings.TrimLeft(integer+fraction, "0123456789") != "" {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if len(strings.TrimLeft(integer, "0")+fraction) > maxAmountDigits {
+		return Amount{}, fmt.Errorf("amount %q has too many digits", s)
+	}
+
+	value, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if strings.HasPrefix(s, "-") {
+		value = -value
+	}
+	return Amount{value: value, scale: len(fraction)}, nil
+}
+
+// MustParseAmount is like ParseAmount but panics if s is not a valid amount
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// String returns the amount as a decimal string, keeping the precision it was created with
+func (a Amount) String() string {
+	if a.scale == 0 {
+		return strconv.FormatInt(a.value, 10)
+	}
+	digits := strconv.FormatInt(a.value, 10)
+	sign := ""
+	if a.value < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= a.scale {
+		digits = strings.Repeat("0", a.scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-a.scale] + "." + digits[len(digits)-a.scale:]
+}
+
+// MinorUnits returns the amount as a number of minor units of currency. It fails when the amount
+// is more precise than the currency allows, e.g. 10.505 EUR.
+func (a Amount) MinorUnits(currency string) (int64, error) {
+	exponent, err := CurrencyExponent(currency)
+	if err != nil {
+		return 0, err
+	}
+	if a.scale > exponent {
+		reduced, ok := a.reduce(exponent)
+		if !ok {
+			return 0, fmt.Errorf("amount %s is more precise than %s allows", a, currency)
+		}
+		return reduced.value, nil
+	}
+	rescaled, ok := a.rescale(exponent)
+	if !ok {
+		return 0, fmt.Errorf("amount %s is too large to count in %s minor units", a, currency)
+	}
+	return rescaled.value, nil
+}
+
+// Add returns a + b. It fails when the sum has too many digits to be held by an Amount.
+func (a Amount) Add(b Amount) (Amount, error) {
+	return a.combine(b, (*big.Int).Add)
+}
+
+// Sub returns a - b. It fails when the difference has too many digits to be held by an Amount.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	return a.combine(b, (*big.Int).Sub)
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b and +1 if a > b
+func (a Amount) Cmp(b Amount) int {
+	scale := maxScale(a, b)
+	return a.bigValue(scale).Cmp(b.bigValue(scale))
+}
+
+// Equal reports whether a and b are the same amount, regardless of their precision
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+// Sign returns -1, 0 or +1 depending on whether the amount is negative, zero or positive
+func (a Amount) Sign() int {
+	return a.Cmp(Amount{})
+}
+
+// IsZero reports whether the amount is 0
+func (a Amount) IsZero() bool {
+	return a.value == 0
+}
+
+// MarshalJSON encodes the amount as a decimal string
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes an amount from a decimal string or a JSON number. An empty string is an amount of 0.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(data, `"`))
+	if s == "" || s == "null" {
+		*a = Amount{}
+		return nil
+	}
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// rescale adds decimals up to scale, reporting false if the value would overflow
+func (a Amount) rescale(scale int) (Amount, bool) {
+	for a.scale < scale {
+		if a.value > math.MaxInt64/10 || a.value < math.MinInt64/10 {
+			return a, false
+		}
+		a.value *= 10
+		a.scale++
+	}
+	return a, true
+}
+
+// reduce drops trailing zero decimals down to scale, reporting false if a non zero decimal would be lost
+func (a Amount) reduce(scale int) (Amount, bool) {
+	for a.scale > scale {
+		if a.value%10 != 0 {
+			return a, false
+		}
+		a.value /= 10
+		a.scale--
+	}
+	return a, true
+}
+
+// bigValue returns the value of the amount counted in units of 10^-scale, which must not be below a.scale
+func (a Amount) bigValue(scale int) *big.Int {
+	exponent := big.NewInt(int64(scale - a.scale))
+	factor := new(big.Int).Exp(big.NewInt(10), exponent, nil)
+	return factor.Mul(factor, big.NewInt(a.value))
+}
+
+// combine applies op to a and b at the precision of the more precise of the two
+func (a Amount) combine(b Amount, op func(z, x, y *big.Int) *big.Int) (Amount, error) {
+	scale := maxScale(a, b)
+	result := op(new(big.Int), a.bigValue(scale), b.bigValue(scale))
+	if !result.IsInt64() {
+		return Amount{}, fmt.Errorf("result of %s and %s has too many digits", a, b)
+	}
+	return Amount{value: result.Int64(), scale: scale}, nil
+}
+
+func maxScale(a, b Amount) int {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+// Money is an Amount in a given currency
+type Money struct {
+	Amount   Amount `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney returns the Money for a number of minor units of currency
+func NewMoney(minor int64, currency string) (Money, error) {
+	amount, err := AmountFromMinorUnits(minor, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Validate checks that the currency is a known ISO 4217 code and the amount is positive
+// and no more precise than the currency allows
+func (m Money) Validate() error {
+	return validateAmount(m.Amount, m.Currency)
+}
+
+// String returns the money as an amount followed by its currency, e.g. "10.50 EUR"
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+func validateAmount(amount Amount, currency string) error {
+	if currency == "" {
+		return errors.New("currency is required")
+	}
+	if amount.Sign() <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	_, err := amount.MinorUnits(currency)
+	return err
+}
+
+// ValidateCurrency checks that code is an active ISO 4217 currency code
+func ValidateCurrency(code string) error {
+	_, err := CurrencyExponent(code)
+	return err
+}
+
+// CurrencyExponent returns the number of decimal places used by the minor unit of an ISO 4217 currency
+func CurrencyExponent(code string) (int, error) {
+	exponent, ok := currencyExponents[code]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", code)
+	}
+	return exponent, nil
+}
+
+// currencyExponents maps active ISO 4217 currency codes to the exponent of their minor unit
+var currencyExponents = map[string]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2, "AWG": 2, "AZN": 2,
+	"BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BHD": 3, "BIF": 0, "BMD": 2, "BND": 2, "BOB": 2, "BRL": 2,
+	"BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2, "CHF": 2, "CLP": 0, "CNY": 2,
+	"COP": 2, "CRC": 2, "CUP": 2, "CVE": 2, "CZK": 2, "DJF": 0, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2,
+	"ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2,
+	"GNF": 0, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2,
+	"IQD": 3, "IRR": 2, "ISK": 0, "JMD": 2, "JOD": 3, "JPY": 0, "KES": 2, "KGS": 2, "KHR": 2, "KMF": 0,
+	"KPW": 2, "KRW": 0, "KWD": 3, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2,
+	"LYD": 3, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2, "MRU": 2, "MUR": 2,
+	"MVR": 2, "MWK": 2, "MXN": 2, "MYR": 2, "MZN": 2, "NAD": 2, "NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2,
+	"NZD": 2, "OMR": 3, "PAB": 2, "PEN": 2, "PGK": 2, "PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2,
+	"RON": 2, "RSD": 2, "RUB": 2, "RWF": 0, "SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2,
+	"SHP": 2, "SLE": 2, "SOS": 2, "SRD": 2, "SSP": 2, "STN": 2, "SVC": 2, "SYP": 2, "SZL": 2, "THB": 2,
+	"TJS": 2, "TMT": 2, "TND": 3, "TOP": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0,
+	"USD": 2, "UYU": 2, "UZS": 2, "VES": 2, "VND": 0, "VUV": 0, "WST": 2, "XAF": 0, "XCD": 2, "XOF": 0,
+	"XPF": 0, "YER": 2, "ZAR": 2, "ZMW": 2, "ZWL": 2,
+}
@@ -0,0 +1,165 @@
+package gomomo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		out     string
+		wantErr bool
+	}{
+		{in: "500", out: "500"},
+		{in: "10.50", out: "10.50"},
+		{in: "0.05", out: "0.05"},
+		{in: "-1.5", out: "-1.5"},
+		{in: "", wantErr: true},
+		{in: ".5", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "12.3.4", wantErr: true},
+		{in: "1234567890123456789", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseAmount(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q) expected an error", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q) unexpected error %s", tt.in, err)
+			continue
+		}
+		if a.String() != tt.out {
+			t.Errorf("ParseAmount(%q) = %s, expected %s", tt.in, a, tt.out)
+		}
+	}
+}
+
+func TestAmount_MinorUnits(t *testing.T) {
+	minor, err := MustParseAmount("10.5").MinorUnits("EUR")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if minor != 1050 {
+		t.Errorf("Expected 1050 minor units but got %d", minor)
+	}
+
+	minor, err = MustParseAmount("500.00").MinorUnits("UGX")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if minor != 500 {
+		t.Errorf("Expected 500 minor units but got %d", minor)
+	}
+
+	if _, err := MustParseAmount("500.5").MinorUnits("UGX"); err == nil {
+		t.Errorf("Expected an error for a fractional UGX amount")
+	}
+	if _, err := MustParseAmount("500").MinorUnits("XYZ"); err == nil {
+		t.Errorf("Expected an error for an unknown currency")
+	}
+
+	a, err := AmountFromMinorUnits(1050, "EUR")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if a.String() != "10.50" {
+		t.Errorf("Expected 10.50 but got %s", a)
+	}
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	a := MustParseAmount("10.50")
+	b := MustParseAmount("0.5")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "11.00" {
+		t.Errorf("Expected 11.00 but got %s, %v", sum, err)
+	}
+	if diff, err := b.Sub(a); err != nil || diff.String() != "-10.00" || diff.Sign() != -1 {
+		t.Errorf("Expected -10.00 but got %s, %v", diff, err)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 {
+		t.Errorf("Expected 10.50 to be greater than 0.5")
+	}
+	if !MustParseAmount("11").Equal(sum) {
+		t.Errorf("Expected 11 to equal 11.00")
+	}
+	if !(Amount{}).IsZero() {
+		t.Errorf("Expected the zero Amount to be zero")
+	}
+}
+
+func TestAmount_Overflow(t *testing.T) {
+	largest := MustParseAmount("999999999999999999")
+
+	if _, err := largest.MinorUnits("EUR"); err == nil {
+		t.Errorf("Expected an error counting %s in EUR cents", largest)
+	}
+	if minor, err := largest.MinorUnits("UGX"); err != nil || minor != 999999999999999999 {
+		t.Errorf("Expected 999999999999999999 UGX but got %d, %v", minor, err)
+	}
+	if minor, err := MustParseAmount("9999999999999999.99").MinorUnits("EUR"); err != nil || minor != 999999999999999999 {
+		t.Errorf("Expected 999999999999999999 EUR cents but got %d, %v", minor, err)
+	}
+	if err := (Money{Amount: largest, Currency: "EUR"}).Validate(); err == nil {
+		t.Errorf("Expected an error validating %s EUR", largest)
+	}
+
+	if sum, err := largest.Add(MustParseAmount("0.01")); err == nil {
+		t.Errorf("Expected an error adding 0.01 to %s but got %s", largest, sum)
+	}
+	if diff, err := MustParseAmount("-999999999999999999").Sub(MustParseAmount("0.01")); err == nil {
+		t.Errorf("Expected an error subtracting 0.01 from -%s but got %s", largest, diff)
+	}
+	if sum, err := largest.Add(largest); err != nil || sum.String() != "1999999999999999998" {
+		t.Errorf("Expected 1999999999999999998 but got %s, %v", sum, err)
+	}
+	if largest.Cmp(MustParseAmount("0.01")) != 1 || MustParseAmount("0.01").Cmp(largest) != -1 {
+		t.Errorf("Expected %s to be greater than 0.01", largest)
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	balance := &BalanceResponse{}
+	err := json.Unmarshal([]byte(`{"availableBalance": "1234567.89", "currency": "EUR"}`), balance)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if balance.AvailableBalance.String() != "1234567.89" {
+		t.Errorf("Expected 1234567.89 but got %s", balance.AvailableBalance)
+	}
+
+	err = json.Unmarshal([]byte(`{"availableBalance": 42.1, "currency": "EUR"}`), balance)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if balance.AvailableBalance.String() != "42.1" {
+		t.Errorf("Expected 42.1 but got %s", balance.AvailableBalance)
+	}
+
+	body, err := json.Marshal(Money{Amount: MustParseAmount("0.10"), Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if string(body) != `{"amount":"0.10","currency":"EUR"}` {
+		t.Errorf("Expected amount to be marshalled as a string but got %s", body)
+	}
+}
+
+func TestMoney_Validate(t *testing.T) {
+	if err := (Money{Amount: NewAmount(500), Currency: "UGX"}).Validate(); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	if err := (Money{Amount: NewAmount(0), Currency: "UGX"}).Validate(); err == nil {
+		t.Errorf("Expected an error for a zero amount")
+	}
+	if err := (Money{Amount: NewAmount(500), Currency: "UGS"}).Validate(); err == nil {
+		t.Errorf("Expected an error for an unknown currency")
+	}
+}
@@ -0,0 +1,106 @@
+package gomomo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client) error
+
+// WithHTTPClient makes the Client send all requests with httpClient, e.g. to use a proxy,
+// mutual TLS or an instrumented transport
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("gomomo: nil http.Client")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets the base URL of the Momo API, e.g. SandboxBaseURL or ProductionBaseURL
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return errors.New("gomomo: base URL must be absolute: " + baseURL)
+		}
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// WithEnvironment sets the target environment sent as X-Target-Environment
+func WithEnvironment(environment string) Option {
+	return func(c *Client) error {
+		c.Environment = environment
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithTimeout limits the time taken by every HTTP request. It is applied to a copy of the
+// http.Client once all options have run, so it may be combined with WithHTTPClient in any order.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.httpTimeout = &timeout
+		return nil
+	}
+}
+
+// WithSubscriptionKey sets the subscription key sent to products without their own Credentials
+func WithSubscriptionKey(key string) Option {
+	return func(c *Client) error {
+		c.SubscriptionKey = key
+		return nil
+	}
+}
+
+// WithCredentials sets the subscription key and API user used for requests to the given product
+func WithCredentials(p Product, creds Credentials) Option {
+	return func(c *Client) error {
+		if c.TokenSource(p) == nil {
+			return errors.New("gomomo: unknown product " + string(p))
+		}
+		c.SetCredentials(p, creds)
+		return nil
+	}
+}
+
+// WithOperationTimeout sets the default timeout of a single operation. See Client.SetTimeout.
+func WithOperationTimeout(op Operation, timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.SetTimeout(op, timeout)
+		return nil
+	}
+}
+
+// WithDefaultTimeout sets the timeout of operations without their own timeout. Zero disables it.
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.DefaultTimeout = timeout
+		return nil
+	}
+}
+
+// WithRateLimit throttles the requests sent to a product. See RateLimit.
+func WithRateLimit(p Product, limit RateLimit) Option {
+	return func(c *Client) error {
+		c.SetRateLimit(p, limit)
+		return nil
+	}
+}
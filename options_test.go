@@ -0,0 +1,73 @@
+package gomomo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	t.Run("NewClient defaults to the sandbox", func(t *testing.T) {
+		c, err := NewClient()
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if c.BaseURL.String() != SandboxBaseURL {
+			t.Errorf("Expected base URL %s but got %s", SandboxBaseURL, c.BaseURL)
+		}
+		if c.Environment != EnvironmentSandbox {
+			t.Errorf("Expected environment %s but got %s", EnvironmentSandbox, c.Environment)
+		}
+	})
+
+	t.Run("NewClient returns an error for an invalid base URL", func(t *testing.T) {
+		for _, baseURL := range []string{"://momo", "momodeveloper.mtn.com"} {
+			if _, err := NewClient(WithBaseURL(baseURL)); err == nil {
+				t.Errorf("Expected an error for base URL %q", baseURL)
+			}
+		}
+	})
+
+	t.Run("WithTimeout does not modify the given http.Client", func(t *testing.T) {
+		httpClient := &http.Client{}
+		c, err := NewClient(WithHTTPClient(httpClient), WithTimeout(10*time.Second), WithBaseURL(ProductionBaseURL))
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if c.client.Timeout != 10*time.Second {
+			t.Errorf("Expected a 10s timeout but got %s", c.client.Timeout)
+		}
+		if httpClient.Timeout != 0 {
+			t.Errorf("Expected the given http.Client to be left unchanged")
+		}
+	})
+
+	t.Run("WithTimeout applies when given before WithHTTPClient", func(t *testing.T) {
+		httpClient := &http.Client{}
+		c, err := NewClient(WithTimeout(5*time.Second), WithHTTPClient(httpClient))
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if c.client.Timeout != 5*time.Second {
+			t.Errorf("Expected a 5s timeout but got %s", c.client.Timeout)
+		}
+		if httpClient.Timeout != 0 {
+			t.Errorf("Expected the given http.Client to be left unchanged")
+		}
+	})
+
+	t.Run("WithUserAgent sets the User-Agent header", func(t *testing.T) {
+		setup()
+		defer teardown()
+		mux.HandleFunc(collectionsBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+			testHeaders(t, r, headers{"User-Agent": "billing/1.0"})
+			w.Write([]byte(`{"availableBalance": "500", "currency": "EUR"}`))
+		})
+		if err := WithUserAgent("billing/1.0")(client); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if _, err := client.Collection.GetBalance(ctx); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+	})
+}
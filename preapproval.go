@@ -0,0 +1,194 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	collectionsPreApprovalURL          = "/collection/v2_0/preapproval"
+	collectionsApprovedPreApprovalsURL = "/collection/v1_0/preapprovals"
+	collectionsCancelPreApprovalURL    = "/collection/v1_0/preapproval"
+)
+
+// PreApprovalRequest holds the details of a pre-approval (standing mandate) requested from a Payer,
+// which allows later payments to be collected without the payer approving each one.
+type PreApprovalRequest struct {
+	Payer         Party  `json:"payer"`
+	PayerCurrency string `json:"payerCurrency"`
+	PayerMessage  string `json:"payerMessage,omitempty"`
+	// Validity is how long the pre-approval stays valid once approved. It is sent in whole seconds.
+	Validity time.Duration `json:"-"`
+
+	// CallbackURL is where Momo sends the final status of the request, sent as X-Callback-Url
+	CallbackURL string `json:"-"`
+	// ReferenceID, when set, is used as the X-Reference-Id of the request. See WithReferenceID.
+	ReferenceID string `json:"-"`
+}
+
+// Validate checks that the request has a payer, a known currency and a validity of at least a second
+func (r *PreApprovalRequest) Validate() error {
+	if err := r.Payer.Validate(); err != nil {
+		return fmt.Errorf("payer: %v", err)
+	}
+	if err := ValidateCurrency(r.PayerCurrency); err != nil {
+		return err
+	}
+	if r.Validity < time.Second {
+		return errors.New("validity must be at least one second")
+	}
+	return nil
+}
+
+// MarshalJSON encodes the request with its Validity as the validityTime in seconds expected by Momo
+func (r PreApprovalRequest) MarshalJSON() ([]byte, error) {
+	type preApprovalRequest PreApprovalRequest
+	return json.Marshal(struct {
+		preApprovalRequest
+		ValidityTime int64 `json:"validityTime"`
+	}{
+		preApprovalRequest: preApprovalRequest(r),
+		ValidityTime:       int64(r.Validity / time.Second),
+	})
+}
+
+// ErrorReason explains why a pre-approval, invoice or payment failed
+type ErrorReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PreApprovalStatusResponse holds the status of a pre-approval created with CreatePreApproval
+type PreApprovalStatusResponse struct {
+	Payer              Party             `json:"payer"`
+	PayerCurrency      string            `json:"payerCurrency"`
+	PayerMessage       string            `json:"payerMessage,omitempty"`
+	Status             TransactionStatus `json:"status"`
+	ExpirationDateTime string            `json:"expirationDateTime,omitempty"`
+	Reason             *ErrorReason      `json:"reason,omitempty"`
+}
+
+// PreApproval is a pre-approval granted by a payer
+type PreApproval struct {
+	PreApprovalID   string `json:"preApprovalId"`
+	ToFri           string `json:"toFri"`
+	FromFri         string `json:"fromFri"`
+	FromCurrency    string `json:"fromCurrency"`
+	CreatedTime     string `json:"createdTime"`
+	ApprovedTime    string `json:"approvedTime"`
+	ExpiryTime      string `json:"expiryTime"`
+	Status          string `json:"status"`
+	Message         string `json:"message"`
+	Frequency       string `json:"frequency"`
+	StartDate       string `json:"startDate"`
+	LastPaymentDate string `json:"lastPaymentDate"`
+}
+
+// CreatePreApproval asks a payer to pre-approve future payments and returns the reference ID of the request.
+// A request whose reference ID already exists is reported as a success.
+func (c *CollectionServiceOp) CreatePreApproval(ctx context.Context, r *PreApprovalRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationCreatePreApproval)
+	defer cancel()
+
+	err := r.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	requestBody := *r
+	requestBody.PayerCurrency, err = c.client.applyCurrencyRule(r.PayerCurrency)
+	if err != nil {
+		return "", err
+	}
+
+	return c.client.create(ctx, collectionsPreApprovalURL, requestBody, r.ReferenceID, r.CallbackURL, func(ctx context.Context, referenceID string) error {
+		_, err := c.GetPreApprovalStatus(ctx, referenceID)
+		return err
+	})
+}
+
+// GetPreApprovalStatus retrieves the status of a pre-approval using the reference ID returned by CreatePreApproval
+func (c *CollectionServiceOp) GetPreApprovalStatus(ctx context.Context, referenceID string) (*PreApprovalStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetPreApprovalStatus)
+	defer cancel()
+
+	urlStr := fmt.Sprintf("%s/%s", collectionsPreApprovalURL, referenceID)
+	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	status := &PreApprovalStatusResponse{}
+	err = json.Unmarshal(res.Body, status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetApprovedPreApprovals lists the pre-approvals granted by a payer
+func (c *CollectionServiceOp) GetApprovedPreApprovals(ctx context.Context, payer Party) ([]PreApproval, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetApprovedPreApprovals)
+	defer cancel()
+
+	if err := payer.Validate(); err != nil {
+		return nil, fmt.Errorf("payer: %v", err)
+	}
+
+	urlStr := fmt.Sprintf("%s/%s", collectionsApprovedPreApprovalsURL, payer.path())
+	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	var preApprovals []PreApproval
+	err = json.Unmarshal(res.Body, &preApprovals)
+	if err != nil {
+		return nil, err
+	}
+	return preApprovals, nil
+}
+
+// CancelPreApproval cancels a pre-approval so that no further payments can be collected with it
+func (c *CollectionServiceOp) CancelPreApproval(ctx context.Context, preApprovalID string) error {
+	ctx, cancel := c.client.withTimeout(ctx, OperationCancelPreApproval)
+	defer cancel()
+
+	urlStr := fmt.Sprintf("%s/%s", collectionsCancelPreApprovalURL, preApprovalID)
+	req, err := c.client.NewRequest(ctx, http.MethodDelete, urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newErrorResponse(res)
+	}
+	return nil
+}
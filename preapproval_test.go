@@ -0,0 +1,140 @@
+package gomomo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCollectionServiceOp_CreatePreApproval(t *testing.T) {
+	t.Run("CreatePreApproval returns 202_ACCEPTED", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		mux.HandleFunc(collectionsPreApprovalURL, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if body["validityTime"] != float64(86400) {
+				t.Errorf("Expected a validityTime of 86400 seconds but got %v", body["validityTime"])
+			}
+			if body["payerCurrency"] != "EUR" {
+				t.Errorf("Expected payerCurrency EUR but got %v", body["payerCurrency"])
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+
+		referenceID, err := client.Collection.CreatePreApproval(ctx, &PreApprovalRequest{
+			Payer:         Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+			PayerCurrency: "EUR",
+			PayerMessage:  "Monthly subscription",
+			Validity:      24 * time.Hour,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if referenceID == "" {
+			t.Errorf("Expected referenceID to be a non empty string")
+		}
+	})
+
+	t.Run("CreatePreApproval rejects a missing validity", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		_, err := client.Collection.CreatePreApproval(ctx, &PreApprovalRequest{
+			Payer:         Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+			PayerCurrency: "EUR",
+		})
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+	})
+}
+
+func TestCollectionServiceOp_GetPreApprovalStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expectedStatus := PreApprovalStatusResponse{
+		Payer:              Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+		PayerCurrency:      "EUR",
+		PayerMessage:       "Monthly subscription",
+		Status:             StatusFailed,
+		ExpirationDateTime: "2021-08-22T10:05:00.000Z",
+		Reason:             &ErrorReason{Code: "APPROVAL_REJECTED", Message: "Payer rejected the pre-approval"},
+	}
+	referenceID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+
+	mux.HandleFunc(fmt.Sprintf("%s/%s", collectionsPreApprovalURL, referenceID), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		json.NewEncoder(w).Encode(expectedStatus)
+	})
+
+	actualStatus, err := client.Collection.GetPreApprovalStatus(ctx, referenceID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !reflect.DeepEqual(actualStatus, &expectedStatus) {
+		t.Errorf("GetPreApprovalStatus\n got=%#v\nwant=%#v", actualStatus, expectedStatus)
+	}
+}
+
+func TestCollectionServiceOp_GetApprovedPreApprovals(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(collectionsApprovedPreApprovalsURL+"/msisdn/25678999720", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"preApprovalId": "ab12", "fromFri": "FRI:25678999720/MSISDN", "fromCurrency": "EUR", "status": "APPROVED", "frequency": "monthly"}]`)
+	})
+
+	preApprovals, err := client.Collection.GetApprovedPreApprovals(ctx, Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if len(preApprovals) != 1 || preApprovals[0].PreApprovalID != "ab12" || preApprovals[0].Frequency != "monthly" {
+		t.Errorf("Unexpected pre-approvals %#v", preApprovals)
+	}
+
+	mux.HandleFunc(collectionsApprovedPreApprovalsURL+"/email/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != collectionsApprovedPreApprovalsURL+"/email/a#b@example.com" {
+			t.Errorf("Expected the payer ID to be escaped but got path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `[{"preApprovalId": "cd34", "fromFri": "FRI:a#b@example.com/EMAIL", "fromCurrency": "EUR", "status": "APPROVED"}]`)
+	})
+
+	preApprovals, err = client.Collection.GetApprovedPreApprovals(ctx, Party{PartyIDType: PartyIDTypeEmail, PartyID: "a#b@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if len(preApprovals) != 1 || preApprovals[0].PreApprovalID != "cd34" {
+		t.Errorf("Unexpected pre-approvals %#v", preApprovals)
+	}
+}
+
+func TestCollectionServiceOp_CancelPreApproval(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(collectionsCancelPreApprovalURL+"/ab12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(collectionsCancelPreApprovalURL+"/cd34", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"RESOURCE_NOT_FOUND","message":"Requested resource was not found."}`)
+	})
+
+	if err := client.Collection.CancelPreApproval(ctx, "ab12"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if err := client.Collection.CancelPreApproval(ctx, "cd34"); !IsNotFound(err) {
+		t.Errorf("Expected a not found error but got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package gomomo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit configures client side throttling of the requests sent to a product
+type RateLimit struct {
+	// Rate is the sustained number of requests per second. Zero disables the rate limit.
+	Rate float64
+	// Burst is the number of requests that may be sent at once before Rate applies. Defaults to 1.
+	Burst int
+	// MaxInFlight caps the number of concurrent requests. Zero disables the cap.
+	MaxInFlight int
+}
+
+// RateLimitStats reports how requests to a product have been throttled
+type RateLimitStats struct {
+	// Requests is the number of requests let through
+	Requests int64
+	// Waiting is the number of requests currently queued
+	Waiting int
+	// InFlight is the number of requests currently being sent
+	InFlight int
+	// TotalWait is the time requests have spent queued
+	TotalWait time.Duration
+	// MaxWait is the longest time a single request was queued
+	MaxWait time.Duration
+}
+
+// limiter is a token bucket combined with a semaphore capping concurrent requests
+type limiter struct {
+	limit RateLimit
+	sem   chan struct{}
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+func newLimiter(limit RateLimit) *limiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	l := &limiter{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+	if limit.MaxInFlight > 0 {
+		l.sem = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// acquire blocks until a request may be sent or ctx is done. The returned func must be
+// called once the request has completed.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	l.mu.Lock()
+	l.stats.Waiting++
+	l.mu.Unlock()
+
+	err := l.wait(ctx)
+
+	waited := time.Since(start)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Waiting--
+	if err != nil {
+		return nil, err
+	}
+	l.stats.Requests++
+	l.stats.InFlight++
+	l.stats.TotalWait += waited
+	if waited > l.stats.MaxWait {
+		l.stats.MaxWait = waited
+	}
+	return l.release, nil
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if l.limit.Rate <= 0 {
+		return nil
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		if l.sem != nil {
+			<-l.sem
+		}
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token from the bucket and returns how long to wait until it is available
+func (l *limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.limit.Rate
+	if l.tokens > float64(l.limit.Burst) {
+		l.tokens = float64(l.limit.Burst)
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.limit.Rate * float64(time.Second))
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	l.stats.InFlight--
+	l.mu.Unlock()
+	if l.sem != nil {
+		<-l.sem
+	}
+}
+
+func (l *limiter) snapshot() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// SetRateLimit throttles the requests sent to a product. It must be called before the Client is used.
+func (c *Client) SetRateLimit(p Product, limit RateLimit) {
+	if c.limiters == nil {
+		c.limiters = map[Product]*limiter{}
+	}
+	c.limiters[p] = newLimiter(limit)
+}
+
+// RateLimitStats returns the throttling statistics of a product. It is empty for a product without a RateLimit.
+func (c *Client) RateLimitStats(p Product) RateLimitStats {
+	if l, ok := c.limiters[p]; ok {
+		return l.snapshot()
+	}
+	return RateLimitStats{}
+}
@@ -0,0 +1,93 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_RateLimit(t *testing.T) {
+	t.Run("Requests are spaced out by the rate limit", func(t *testing.T) {
+		setup()
+		defer teardown()
+		mux.HandleFunc(disbursementsBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"availableBalance": "500", "currency": "EUR"}`)
+		})
+		client.SetRateLimit(ProductDisbursement, RateLimit{Rate: 50, Burst: 1})
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			if _, err := client.Disbursement.GetBalance(ctx); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+			t.Errorf("Expected 5 requests at 50/s to take at least 80ms but took %s", elapsed)
+		}
+
+		stats := client.RateLimitStats(ProductDisbursement)
+		if stats.Requests != 5 || stats.Waiting != 0 || stats.InFlight != 0 {
+			t.Errorf("Unexpected stats %+v", stats)
+		}
+		if stats.TotalWait <= 0 || stats.MaxWait <= 0 {
+			t.Errorf("Expected queue times to be recorded but got %+v", stats)
+		}
+		if other := client.RateLimitStats(ProductCollection); other.Requests != 0 {
+			t.Errorf("Expected Collection to be unaffected but got %+v", other)
+		}
+	})
+
+	t.Run("MaxInFlight caps concurrent requests", func(t *testing.T) {
+		setup()
+		defer teardown()
+		var mu sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		mux.HandleFunc(disbursementsBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			fmt.Fprint(w, `{"availableBalance": "500", "currency": "EUR"}`)
+		})
+		client.SetRateLimit(ProductDisbursement, RateLimit{MaxInFlight: 2})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Disbursement.GetBalance(ctx); err != nil {
+					t.Errorf("unexpected error %s", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if maxInFlight > 2 {
+			t.Errorf("Expected at most 2 concurrent requests but got %d", maxInFlight)
+		}
+	})
+
+	t.Run("Queued requests give up when the context is done", func(t *testing.T) {
+		setup()
+		defer teardown()
+		client.SetRateLimit(ProductCollection, RateLimit{Rate: 0.1, Burst: 1})
+		client.Collection.GetBalance(ctx)
+
+		deadline, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := client.Collection.GetBalance(deadline)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded but got %v", err)
+		}
+	})
+}
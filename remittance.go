@@ -8,19 +8,26 @@ import (
 )
 
 const (
-	remittancesTokenURL           = "/remittance/token/"
-	remittancesTransferURL        = "/remittance/v1_0/transfer"
-	remittancesBalanceURL         = "/remittance/v1_0/account/balance"
-	remittancesIsAccountActiveURL = "/remittance/v1_0/accountholder/msisdn/"
+	remittancesTokenURL    = "/remittance/token/"
+	remittancesTransferURL = "/remittance/v1_0/transfer"
+	remittancesBalanceURL  = "/remittance/v1_0/account/balance"
 )
 
 // RemittanceService handles communication with Remittance related methods of the
 // Momo API to remit funds to local recipients from the diaspora
 type RemittanceService interface {
 	Transfer(ctx context.Context, mobile string, amount int64, id, payeeNote, payerMessage, currency string) (string, error)
+	CreateTransfer(ctx context.Context, r *TransferRequest) (string, error)
 	GetTransfer(ctx context.Context, transactionID string) (*PaymentStatusResponse, error)
+	WaitForTransfer(ctx context.Context, transferID string, opts *WaitOptions) (*PaymentStatusResponse, error)
+	CashTransfer(ctx context.Context, r *CashTransferRequest) (string, error)
+	GetCashTransferStatus(ctx context.Context, referenceID string) (*CashTransferStatusResponse, error)
 	GetBalance(ctx context.Context) (*BalanceResponse, error)
+	GetBalanceInCurrency(ctx context.Context, currency string) (*BalanceResponse, error)
+	GetBalances(ctx context.Context, currencies []string) (map[string]*BalanceResponse, error)
 	IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error)
+	ValidateAccountHolder(ctx context.Context, party Party) (AccountStatus, error)
+	GetBasicUserInfo(ctx context.Context, party Party) (*BasicUserInfo, error)
 	GetToken(ctx context.Context, apiKey, userID string) (string, error)
 }
 
@@ -31,108 +38,55 @@ type RemittanceServiceOp struct {
 
 // GetBalance returns the balance of the account
 func (c *RemittanceServiceOp) GetBalance(ctx context.Context) (*BalanceResponse, error) {
-	req, err := c.client.NewRequest(ctx, http.MethodGet, remittancesBalanceURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
-
-	balance := &BalanceResponse{}
-	err = json.Unmarshal(res.Body, balance)
-	if err != nil {
-		return nil, err
-	}
-	return balance, nil
+	return c.client.getBalance(ctx, remittancesBalanceURL)
 }
 
-// IsPayeeActive checks if an account holder is registered and active in the system
+// IsPayeeActive checks if an account holder identified by their mobile number is registered and active in the system.
+// It is a shorthand for ValidateAccountHolder.
 func (c *RemittanceServiceOp) IsPayeeActive(ctx context.Context, mobileNumber string) (bool, error) {
-	urlStr := fmt.Sprintf("%s/%s/active", remittancesIsAccountActiveURL, mobileNumber)
-	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
-	if err != nil {
-		return false, err
-	}
-
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return false, err
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
-
-	return true, nil
+	status, err := c.ValidateAccountHolder(ctx, Party{PartyIDType: PartyIDTypeMSISDN, PartyID: mobileNumber})
+	return status == AccountStatusActive, err
 }
 
-// GetToken creates an access token which can then be used to authorize and authenticate towards the other end-points of the Remittance API
+// GetToken creates an access token which can then be used to authorize and authenticate towards the other end-points of the Remittance API.
+// The credentials are kept on the product's TokenSource, so subsequent requests fetch and refresh tokens automatically.
 func (c *RemittanceServiceOp) GetToken(ctx context.Context, apiKey, userID string) (string, error) {
-
-	req, err := c.client.NewRequest(ctx, http.MethodPost, remittancesTokenURL, nil)
-	if err != nil {
-		return "", err
-	}
-	req.SetBasicAuth(userID, apiKey)
-
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return "", err
-	}
-	token := &tokenResponse{}
-
-	err = json.Unmarshal(res.Body, token)
-	if err != nil {
-		return "", err
-	}
-	c.client.Token = token.AccessToken
-	return token.AccessToken, nil
+	ts := c.client.TokenSource(ProductRemittance)
+	ts.SetCredentials(userID, apiKey)
+	return ts.Token(ctx)
 }
 
-// Transfer operation is used to transfer an amount from the owner’s account to a payee account.
+// Transfer operation is used to transfer an amount from the owner’s account to a payee identified by their mobile number.
+// It is a shorthand for CreateTransfer.
 func (c *RemittanceServiceOp) Transfer(ctx context.Context, mobile string, amount int64, id, payeeNote, payerMessage, currency string) (string, error) {
-	if c.client.Environment == "sandbox" {
-		currency = "EUR"
-	}
-
-	requestBody := transferRequestBody{
-		Amount:     amount,
+	return c.CreateTransfer(ctx, &TransferRequest{
+		Amount:     NewAmount(amount),
 		Currency:   currency,
 		ExternalID: id,
-		Payee: paymentDetails{
-			PartyIDType: "MSISDN",
+		Payee: Party{
+			PartyIDType: PartyIDTypeMSISDN,
 			PartyID:     mobile,
 		},
 		PayerMessage: payerMessage,
 		PayeeNote:    payeeNote,
-	}
-
-	req, err := c.client.NewRequest(ctx, http.MethodPost, remittancesTransferURL, requestBody)
-	if err != nil {
-		return "", err
-	}
-
-	res, err := c.client.Do(ctx, req)
-	if err != nil {
-		return "", err
-	}
+	})
+}
 
-	if res.StatusCode != http.StatusAccepted {
-		return "", fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
-	}
+// CreateTransfer is used to transfer an amount from the owner’s account to a payee account. Set ReferenceID on the
+// request or use WithReferenceID on ctx to resend a transfer safely; a transfer whose reference ID already exists
+// is reported as a success.
+func (c *RemittanceServiceOp) CreateTransfer(ctx context.Context, r *TransferRequest) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationTransfer)
+	defer cancel()
 
-	return req.Header.Get("X-Reference-Id"), nil
+	return c.client.sendTransfer(ctx, remittancesTransferURL, r, c.GetTransfer)
 }
 
 // GetTransfer retrieves transfer information using the transactionId returned by Transfer
 func (c *RemittanceServiceOp) GetTransfer(ctx context.Context, transferID string) (*PaymentStatusResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGetTransfer)
+	defer cancel()
+
 	urlStr := fmt.Sprintf("%s/%s", remittancesTransferURL, transferID)
 	req, err := c.client.NewRequest(ctx, http.MethodGet, urlStr, nil)
 	if err != nil {
@@ -145,7 +99,7 @@ func (c *RemittanceServiceOp) GetTransfer(ctx context.Context, transferID string
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code: %d with error %s", res.StatusCode, string(res.Body))
+		return nil, newErrorResponse(res)
 	}
 
 	status := &PaymentStatusResponse{}
@@ -26,8 +26,8 @@ func TestRemittanceServiceOp_GetToken(t *testing.T) {
 			t.Errorf("Expected 'token' but got %s", token)
 		}
 
-		if client.Token != token {
-			t.Errorf("Expected 'token' to be set on client but got %s", token)
+		if client.Token != "" {
+			t.Errorf("Expected the shared client Token to be left unset but got %s", client.Token)
 		}
 	})
 
@@ -66,16 +66,45 @@ func TestRemittanceServiceOp_Transfer(t *testing.T) {
 	})
 }
 
+func TestRemittanceServiceOp_CreateTransfer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(remittancesTransferURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		body := TransferRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if body.Payee.PartyIDType != PartyIDTypePartyCode || body.Payee.PartyID != "ee67c3b9" {
+			t.Errorf("Expected a PARTY_CODE payee but got %#v", body.Payee)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	transactionID, err := client.Remittance.CreateTransfer(ctx, &TransferRequest{
+		Amount:     NewAmount(500),
+		Currency:   "EUR",
+		ExternalID: "34232",
+		Payee:      Party{PartyIDType: PartyIDTypePartyCode, PartyID: "ee67c3b9"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if transactionID == "" {
+		t.Errorf("Expected transactionID to be a non empty string")
+	}
+}
+
 func TestRemittanceServiceOp_GetTransfer(t *testing.T) {
 	setup()
 	defer teardown()
 
 	expectedStatus := PaymentStatusResponse{
-		Amount:                 "500",
+		Amount:                 MustParseAmount("500"),
 		Currency:               "UGX",
-		FinancialTransactionID: 2312,
+		FinancialTransactionID: "2312",
 		ExternalID:             "3232",
-		Payer: paymentDetails{
+		Payer: Party{
 			PartyIDType: "MSISDN",
 			PartyID:     "4656473839",
 		},
@@ -107,7 +136,7 @@ func TestRemittanceServiceOp_GetBalance(t *testing.T) {
 	defer teardown()
 
 	expectedBalance := BalanceResponse{
-		AvailableBalance: "500",
+		AvailableBalance: MustParseAmount("500"),
 		Currency:         "UGX",
 	}
 
@@ -130,15 +159,18 @@ func TestRemittanceServiceOp_IsPayeeActive(t *testing.T) {
 	setup()
 	defer teardown()
 	mobileNumber := "256789997290"
-	urlStr := fmt.Sprintf("%s%s/active", remittancesIsAccountActiveURL, mobileNumber)
+	urlStr := fmt.Sprintf("%s/msisdn/%s/active", remittancesAccountHolderURL, mobileNumber)
 
 	mux.HandleFunc(urlStr, func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
 		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"result": true}`)
 	})
 
-	_, err := client.Remittance.IsPayeeActive(ctx, mobileNumber)
+	active, err := client.Remittance.IsPayeeActive(ctx, mobileNumber)
 	if err != nil {
 		t.Fatalf("unexpected error %s", err)
 	}
+	if !active {
+		t.Errorf("Expected the payee to be active")
+	}
 }
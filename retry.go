@@ -0,0 +1,140 @@
+package gomomo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how Client.Do retries requests that fail with a transient error: a network
+// error, 429 Too Many Requests or a 500, 502, 503 or 504 response.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the first. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries. A longer Retry-After sent by Momo is still honoured.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every retry
+	Multiplier float64
+	// Jitter randomises each delay by up to this fraction of it, between 0 and 1
+	Jitter float64
+	// RetryPOST allows POST requests such as RequestToPay and Transfer to be retried when the caller pinned
+	// their reference ID, with WithReferenceID or the ReferenceID of the request. A retry reuses that
+	// X-Reference-Id, so Momo rejects it as a duplicate instead of creating a second transaction, and the
+	// caller can look the transaction up if every attempt fails. By default only GET requests are retried.
+	RetryPOST bool
+}
+
+// DefaultRetryPolicy retries GET requests up to twice
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// NoRetry disables retries
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// WithRetryPolicy returns a copy of ctx which makes requests sent with it follow policy
+// instead of the Client's RetryPolicy.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey, policy)
+}
+
+func (c *Client) retryPolicy(ctx context.Context) RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey).(RetryPolicy); ok {
+		return policy
+	}
+	return c.RetryPolicy
+}
+
+// attempts returns the number of times req, sent with ctx, may be sent. POST requests are only
+// retried when ctx pins their reference ID.
+func (p RetryPolicy) attempts(ctx context.Context, req *http.Request) int {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		if !p.RetryPOST || !hasPinnedReferenceID(ctx, req) {
+			return 1
+		}
+	default:
+		return 1
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 1
+	}
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// hasPinnedReferenceID reports whether the X-Reference-Id of req was set by the caller through ctx
+// rather than generated when req was created
+func hasPinnedReferenceID(ctx context.Context, req *http.Request) bool {
+	referenceID, ok := ctx.Value(referenceIDKey).(string)
+	return ok && referenceID != "" && referenceID == req.Header.Get("X-Reference-Id")
+}
+
+// delay returns how long to wait before retry number retry, preferring the Retry-After of the response
+func (p RetryPolicy) delay(retry int, response *Response) time.Duration {
+	if response != nil {
+		if after, ok := retryAfter(http.Header(response.Headers).Get("Retry-After")); ok {
+			return after
+		}
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff < p.InitialBackoff {
+		maxBackoff = p.InitialBackoff
+	}
+	return backoff(retry, p.InitialBackoff, maxBackoff, multiplier, p.Jitter)
+}
+
+// shouldRetry reports whether a request that ended with response or err is worth sending again
+func shouldRetry(ctx context.Context, response *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return isRetryableStatus(response.StatusCode)
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		after := time.Until(at)
+		if after < 0 {
+			after = 0
+		}
+		return after, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Do_Retry(t *testing.T) {
+	t.Run("GET requests are retried on transient errors", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(remittancesBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"availableBalance": "500", "currency": "EUR"}`)
+		})
+
+		_, err := client.Remittance.GetBalance(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 attempts but got %d", calls)
+		}
+	})
+
+	t.Run("GET requests give up after MaxAttempts", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(remittancesBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		_, err := client.Remittance.GetBalance(ctx)
+		if !IsRetryable(err) {
+			t.Errorf("Expected a retryable error but got %v", err)
+		}
+		if calls != DefaultRetryPolicy.MaxAttempts {
+			t.Errorf("Expected %d attempts but got %d", DefaultRetryPolicy.MaxAttempts, calls)
+		}
+	})
+
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(remittancesBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		client.Remittance.GetBalance(ctx)
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt but got %d", calls)
+		}
+	})
+
+	t.Run("POST requests are not retried by default", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(disbursementsTransferURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		_, err := client.Disbursement.Transfer(ctx, "25678999720", 500, "34232", "payee", "payer", "EUR")
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt but got %d", calls)
+		}
+	})
+
+	t.Run("POST requests are not retried without a pinned reference ID", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(disbursementsTransferURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		policy := DefaultRetryPolicy
+		policy.InitialBackoff = time.Millisecond
+		policy.RetryPOST = true
+		_, err := client.Disbursement.Transfer(WithRetryPolicy(ctx, policy), "25678999720", 500, "34232", "payee", "payer", "EUR")
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt but got %d", calls)
+		}
+	})
+
+	t.Run("POST requests are retried with the same reference ID when allowed", func(t *testing.T) {
+		referenceID := NewReferenceID()
+		send := map[string]func(ctx context.Context) (string, error){
+			"WithReferenceID": func(ctx context.Context) (string, error) {
+				return client.Disbursement.Transfer(WithReferenceID(ctx, referenceID), "25678999720", 500, "34232", "payee", "payer", "EUR")
+			},
+			"ReferenceID": func(ctx context.Context) (string, error) {
+				return client.Disbursement.CreateTransfer(ctx, &TransferRequest{
+					Amount:      NewAmount(500),
+					Currency:    "EUR",
+					ExternalID:  "34232",
+					Payee:       Party{PartyIDType: PartyIDTypeMSISDN, PartyID: "25678999720"},
+					ReferenceID: referenceID,
+				})
+			},
+		}
+
+		for name, fn := range send {
+			setup()
+			var referenceIDs []string
+			var bodies []string
+			mux.HandleFunc(disbursementsTransferURL, func(w http.ResponseWriter, r *http.Request) {
+				referenceIDs = append(referenceIDs, r.Header.Get("X-Reference-Id"))
+				body, _ := ioutil.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if len(referenceIDs) == 1 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			})
+
+			policy := DefaultRetryPolicy
+			policy.InitialBackoff = time.Millisecond
+			policy.RetryPOST = true
+			transferID, err := fn(WithRetryPolicy(ctx, policy))
+			teardown()
+			if err != nil {
+				t.Fatalf("%s: unexpected error %s", name, err)
+			}
+			if transferID != referenceID {
+				t.Errorf("%s: expected reference ID %s but got %s", name, referenceID, transferID)
+			}
+			if len(referenceIDs) != 2 || referenceIDs[0] != referenceID || referenceIDs[1] != referenceID {
+				t.Errorf("%s: expected both attempts to use reference ID %s but got %v", name, referenceID, referenceIDs)
+			}
+			if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+				t.Errorf("%s: expected the body to be resent unchanged but got %q", name, bodies)
+			}
+		}
+	})
+
+	t.Run("Retries can be disabled per call", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(remittancesBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		client.Remittance.GetBalance(WithRetryPolicy(ctx, NoRetry))
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt but got %d", calls)
+		}
+	})
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second, Multiplier: 2}
+
+	if d := policy.delay(2, &Response{}); d != 4*time.Second {
+		t.Errorf("Expected a 4s backoff but got %s", d)
+	}
+	if d := policy.delay(0, &Response{Headers: http.Header{"Retry-After": {"7"}}}); d != 7*time.Second {
+		t.Errorf("Expected the Retry-After of 7s to be honoured but got %s", d)
+	}
+	at := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d := policy.delay(0, &Response{Headers: http.Header{"Retry-After": {at}}}); d < 55*time.Second || d > time.Minute {
+		t.Errorf("Expected the Retry-After date to be honoured but got %s", d)
+	}
+}
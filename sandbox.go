@@ -7,10 +7,63 @@ import (
 	"net/http"
 )
 
+// MSISDNs that make the Momo sandbox complete requests to pay and transfers with a given status.
+// Transactions with any other MSISDN, such as SandboxMSISDNSuccessful, succeed.
+const (
+	SandboxMSISDNFailed     = "46733123450"
+	SandboxMSISDNRejected   = "46733123451"
+	SandboxMSISDNTimeout    = "46733123452"
+	SandboxMSISDNOngoing    = "46733123453"
+	SandboxMSISDNPending    = "46733123454"
+	SandboxMSISDNSuccessful = "46733123455"
+)
+
+// SandboxScenario is a transaction status the Momo sandbox can be made to return and the MSISDN that triggers it
+type SandboxScenario struct {
+	Status TransactionStatus
+	MSISDN string
+}
+
+// Party returns the party to use as payer or payee to trigger the scenario
+func (s SandboxScenario) Party() Party {
+	return Party{PartyIDType: PartyIDTypeMSISDN, PartyID: s.MSISDN}
+}
+
+// SandboxScenarios lists every transaction status the Momo sandbox can be made to return,
+// e.g. to cover each of them in a table driven test
+var SandboxScenarios = []SandboxScenario{
+	{Status: StatusSuccessful, MSISDN: SandboxMSISDNSuccessful},
+	{Status: StatusFailed, MSISDN: SandboxMSISDNFailed},
+	{Status: StatusRejected, MSISDN: SandboxMSISDNRejected},
+	{Status: StatusTimeout, MSISDN: SandboxMSISDNTimeout},
+	{Status: StatusOngoing, MSISDN: SandboxMSISDNOngoing},
+	{Status: StatusPending, MSISDN: SandboxMSISDNPending},
+}
+
+// SandboxScenarioFor returns the sandbox scenario resulting in status
+func SandboxScenarioFor(status TransactionStatus) (SandboxScenario, error) {
+	for _, scenario := range SandboxScenarios {
+		if scenario.Status == status {
+			return scenario, nil
+		}
+	}
+	return SandboxScenario{}, fmt.Errorf("no sandbox scenario for status %q", status)
+}
+
+// SandboxStatus returns the status the Momo sandbox gives transactions with msisdn
+func SandboxStatus(msisdn string) TransactionStatus {
+	for _, scenario := range SandboxScenarios {
+		if scenario.MSISDN == msisdn {
+			return scenario.Status
+		}
+	}
+	return StatusSuccessful
+}
+
 // SandboxService handles communication with sandbox related methods of the Momo API
 type SandboxService interface {
-	CreateSandboxUser(callbackHost string) (string, error)
-	GenerateSandboxUserAPIKey(referenceID string) (*APIKeyResponse, error)
+	CreateSandboxUser(ctx context.Context, callbackHost string) (string, error)
+	GenerateSandboxUserAPIKey(ctx context.Context, referenceID string) (*APIKeyResponse, error)
 }
 
 // SandboxServiceOp handles communication with methods on Momo API to create Sandbox users
@@ -24,8 +77,10 @@ type APIKeyResponse struct {
 }
 
 // CreateSandboxUser creates a user to test the Momo APU in a sandbox environment
-func (c *SandboxServiceOp) CreateSandboxUser(callbackHost string) (string, error) {
-	ctx := context.Background()
+func (c *SandboxServiceOp) CreateSandboxUser(ctx context.Context, callbackHost string) (string, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationCreateSandboxUser)
+	defer cancel()
+
 	body := map[string]string{
 		"providerCallbackHost": callbackHost,
 	}
@@ -39,15 +94,17 @@ func (c *SandboxServiceOp) CreateSandboxUser(callbackHost string) (string, error
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("response code: %d with error %s", response.StatusCode, string(response.Body))
+		return "", newErrorResponse(response)
 	}
 	return response.ReferenceID, nil
 }
 
 // GenerateSandboxUserAPIKey is used to create an API key for an API user in the sandbox target environment
-func (c *SandboxServiceOp) GenerateSandboxUserAPIKey(referenceID string) (*APIKeyResponse, error) {
+func (c *SandboxServiceOp) GenerateSandboxUserAPIKey(ctx context.Context, referenceID string) (*APIKeyResponse, error) {
+	ctx, cancel := c.client.withTimeout(ctx, OperationGenerateSandboxUserAPIKey)
+	defer cancel()
+
 	urlStr := fmt.Sprintf("v1_0/apiuser/%s/apikey", referenceID)
-	ctx := context.Background()
 	req, err := c.client.NewRequest(ctx, http.MethodPost, urlStr, nil)
 	if err != nil {
 		return nil, err
@@ -58,7 +115,7 @@ func (c *SandboxServiceOp) GenerateSandboxUserAPIKey(referenceID string) (*APIKe
 	}
 
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("response code: %d with error %s", response.StatusCode, string(response.Body))
+		return nil, newErrorResponse(response)
 	}
 
 	keyResponse := &APIKeyResponse{}
@@ -0,0 +1,25 @@
+package gomomo
+
+import "testing"
+
+func TestSandboxScenarios(t *testing.T) {
+	for _, status := range []TransactionStatus{StatusSuccessful, StatusFailed, StatusRejected, StatusTimeout, StatusOngoing, StatusPending} {
+		scenario, err := SandboxScenarioFor(status)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if got := SandboxStatus(scenario.MSISDN); got != status {
+			t.Errorf("SandboxStatus(%s) = %s, want %s", scenario.MSISDN, got, status)
+		}
+		if party := scenario.Party(); party.Validate() != nil || party.PartyID != scenario.MSISDN {
+			t.Errorf("Unexpected party %#v for %s", party, status)
+		}
+	}
+
+	if _, err := SandboxScenarioFor(StatusCancelled); err == nil {
+		t.Errorf("Expected a non nil error for a status without a scenario")
+	}
+	if got := SandboxStatus("256789997290"); got != StatusSuccessful {
+		t.Errorf("SandboxStatus of another MSISDN = %s, want %s", got, StatusSuccessful)
+	}
+}
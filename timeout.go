@@ -0,0 +1,97 @@
+package gomomo
+
+import (
+	"context"
+	"time"
+)
+
+// Operation names a Momo API call for the purpose of configuring its default timeout.
+// Operations are shared between products, e.g. OperationGetBalance covers the balance of every product.
+type Operation string
+
+// Operations of the Momo API
+const (
+	OperationToken                     Operation = "Token"
+	OperationRequestToPay              Operation = "RequestToPay"
+	OperationGetTransaction            Operation = "GetTransaction"
+	OperationRequestToWithdraw         Operation = "RequestToWithdraw"
+	OperationGetWithdrawStatus         Operation = "GetWithdrawStatus"
+	OperationSendDeliveryNotification  Operation = "SendDeliveryNotification"
+	OperationCreatePreApproval         Operation = "CreatePreApproval"
+	OperationGetPreApprovalStatus      Operation = "GetPreApprovalStatus"
+	OperationGetApprovedPreApprovals   Operation = "GetApprovedPreApprovals"
+	OperationCancelPreApproval         Operation = "CancelPreApproval"
+	OperationCreateInvoice             Operation = "CreateInvoice"
+	OperationGetInvoiceStatus          Operation = "GetInvoiceStatus"
+	OperationCancelInvoice             Operation = "CancelInvoice"
+	OperationCreatePayment             Operation = "CreatePayment"
+	OperationGetPaymentStatus          Operation = "GetPaymentStatus"
+	OperationTransfer                  Operation = "Transfer"
+	OperationGetTransfer               Operation = "GetTransfer"
+	OperationDeposit                   Operation = "Deposit"
+	OperationGetDeposit                Operation = "GetDeposit"
+	OperationRefund                    Operation = "Refund"
+	OperationGetRefund                 Operation = "GetRefund"
+	OperationCashTransfer              Operation = "CashTransfer"
+	OperationGetCashTransferStatus     Operation = "GetCashTransferStatus"
+	OperationGetBalance                Operation = "GetBalance"
+	OperationIsPayeeActive             Operation = "IsPayeeActive"
+	OperationGetBasicUserInfo          Operation = "GetBasicUserInfo"
+	OperationAuthorize                 Operation = "Authorize"
+	OperationGetUserToken              Operation = "GetUserToken"
+	OperationGetUserInfoWithConsent    Operation = "GetUserInfoWithConsent"
+	OperationCreateSandboxUser         Operation = "CreateSandboxUser"
+	OperationGenerateSandboxUserAPIKey Operation = "GenerateSandboxUserAPIKey"
+)
+
+// DefaultTimeout is the timeout of operations without an entry in the Client's Timeouts
+const DefaultTimeout = 30 * time.Second
+
+// defaultTimeouts returns the per operation timeouts a Client starts with
+func defaultTimeouts() map[Operation]time.Duration {
+	return map[Operation]time.Duration{
+		OperationToken:                  10 * time.Second,
+		OperationGetBalance:             10 * time.Second,
+		OperationGetTransaction:         10 * time.Second,
+		OperationGetWithdrawStatus:      10 * time.Second,
+		OperationGetTransfer:            10 * time.Second,
+		OperationGetDeposit:             10 * time.Second,
+		OperationGetCashTransferStatus:  10 * time.Second,
+		OperationGetRefund:              10 * time.Second,
+		OperationIsPayeeActive:          10 * time.Second,
+		OperationGetUserToken:           10 * time.Second,
+		OperationGetUserInfoWithConsent: 10 * time.Second,
+		OperationGetBasicUserInfo:       10 * time.Second,
+		OperationRequestToPay:           45 * time.Second,
+		OperationRequestToWithdraw:      45 * time.Second,
+		OperationTransfer:               45 * time.Second,
+		OperationDeposit:                45 * time.Second,
+		OperationCashTransfer:           45 * time.Second,
+		OperationRefund:                 45 * time.Second,
+	}
+}
+
+// SetTimeout sets the default timeout of an operation. A zero timeout falls back to the Client's
+// DefaultTimeout and a negative timeout disables it.
+func (c *Client) SetTimeout(op Operation, timeout time.Duration) {
+	if c.Timeouts == nil {
+		c.Timeouts = map[Operation]time.Duration{}
+	}
+	if timeout == 0 {
+		delete(c.Timeouts, op)
+		return
+	}
+	c.Timeouts[op] = timeout
+}
+
+// withTimeout derives the context of a single operation. An earlier deadline already set on ctx is kept.
+func (c *Client) withTimeout(ctx context.Context, op Operation) (context.Context, context.CancelFunc) {
+	timeout, ok := c.Timeouts[op]
+	if !ok {
+		timeout = c.DefaultTimeout
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,72 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_ContextReachesTransport(t *testing.T) {
+	setup()
+	defer teardown()
+	release := make(chan struct{})
+	defer close(release)
+	mux.HandleFunc(collectionsBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	deadline, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Collection.GetBalance(deadline)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded but got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the request to be cancelled at the deadline but it took %s", elapsed)
+	}
+}
+
+func TestClient_OperationTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+	release := make(chan struct{})
+	defer close(release)
+	mux.HandleFunc("/v1_0/apiuser", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	client.SetTimeout(OperationCreateSandboxUser, 20*time.Millisecond)
+	_, err := client.Sandbox.CreateSandboxUser(ctx, "https://example.com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded but got %v", err)
+	}
+}
+
+func TestClient_SetTimeout(t *testing.T) {
+	c, err := NewClient(WithDefaultTimeout(time.Minute), WithOperationTimeout(OperationGetBalance, -1))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	opCtx, cancel := c.withTimeout(ctx, OperationGetBalance)
+	defer cancel()
+	if _, ok := opCtx.Deadline(); ok {
+		t.Errorf("Expected a negative timeout to disable the deadline")
+	}
+
+	c.SetTimeout(OperationGetBalance, 0)
+	opCtx, cancel = c.withTimeout(ctx, OperationGetBalance)
+	defer cancel()
+	if deadline, ok := opCtx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("Expected the default timeout to apply")
+	}
+}
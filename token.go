@@ -0,0 +1,155 @@
+package gomomo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Product identifies one of the Momo API products. Each product is subscribed to separately
+// and has its own API user and access tokens.
+type Product string
+
+const (
+	// ProductCollection is the Collection product
+	ProductCollection Product = "collection"
+	// ProductDisbursement is the Disbursement product
+	ProductDisbursement Product = "disbursement"
+	// ProductRemittance is the Remittance product
+	ProductRemittance Product = "remittance"
+)
+
+// tokenExpiryDelta is how long before its expiry a cached token is refreshed
+const tokenExpiryDelta = time.Minute
+
+// errNoCredentials is returned when a token is requested from a TokenSource without credentials
+var errNoCredentials = errors.New("gomomo: no API user credentials set for product")
+
+// productFromPath returns the product whose endpoints live under the given URL path
+func productFromPath(path string) Product {
+	segment := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+	switch p := Product(segment); p {
+	case ProductCollection, ProductDisbursement, ProductRemittance:
+		return p
+	}
+	return ""
+}
+
+// TokenSource fetches access tokens for a single Momo product and caches them
+// until shortly before they expire.
+type TokenSource struct {
+	client   *Client
+	tokenURL string
+
+	mu     sync.Mutex
+	userID string
+	apiKey string
+	token  string
+	expiry time.Time
+}
+
+func newTokenSource(c *Client, tokenURL string) *TokenSource {
+	return &TokenSource{client: c, tokenURL: tokenURL}
+}
+
+// SetCredentials sets the API user ID and API key used to fetch tokens and drops any cached token
+func (ts *TokenSource) SetCredentials(userID, apiKey string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.userID = userID
+	ts.apiKey = apiKey
+	ts.token = ""
+	ts.expiry = time.Time{}
+}
+
+// setBasicAuth authorizes req with the API user credentials
+func (ts *TokenSource) setBasicAuth(req *http.Request) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.userID == "" {
+		return errNoCredentials
+	}
+	req.SetBasicAuth(ts.userID, ts.apiKey)
+	return nil
+}
+
+func (ts *TokenSource) hasCredentials() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.userID != ""
+}
+
+// Token returns a valid access token, fetching a new one when none is cached
+// or the cached one is about to expire.
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && !ts.expired() {
+		return ts.token, nil
+	}
+	if ts.userID == "" {
+		return "", errNoCredentials
+	}
+
+	token, err := ts.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	ts.token = token.AccessToken
+	ts.expiry = time.Time{}
+	if token.ExpiresIn > 0 {
+		ts.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return ts.token, nil
+}
+
+// invalidate drops the cached token if it is still the given token, so that
+// the next call to Token fetches a new one.
+func (ts *TokenSource) invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token == token {
+		ts.token = ""
+		ts.expiry = time.Time{}
+	}
+}
+
+// expired reports whether the cached token expires within tokenExpiryDelta.
+// A token without an expiry is kept until the API rejects it.
+func (ts *TokenSource) expired() bool {
+	if ts.expiry.IsZero() {
+		return false
+	}
+	return time.Now().Add(tokenExpiryDelta).After(ts.expiry)
+}
+
+func (ts *TokenSource) fetch(ctx context.Context) (*tokenResponse, error) {
+	ctx, cancel := ts.client.withTimeout(ctx, OperationToken)
+	defer cancel()
+
+	req, err := ts.client.newRequest(ctx, http.MethodPost, ts.tokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(ts.userID, ts.apiKey)
+
+	res, err := ts.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, newErrorResponse(res)
+	}
+
+	token := &tokenResponse{}
+	err = json.Unmarshal(res.Body, token)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
@@ -0,0 +1,118 @@
+package gomomo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenSource_Token(t *testing.T) {
+	t.Run("Token is fetched once and cached", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(collectionsTokenURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			testMethod(t, r, http.MethodPost)
+			if user, key, ok := r.BasicAuth(); !ok || user != "user" || key != "key" {
+				t.Errorf("Expected basic auth user:key but got %s:%s", user, key)
+			}
+			fmt.Fprint(w, `{"access_token": "token", "token_type": "access_token", "expires_in": 3600}`)
+		})
+
+		ts := client.TokenSource(ProductCollection)
+		ts.SetCredentials("user", "key")
+		for i := 0; i < 2; i++ {
+			token, err := ts.Token(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if token != "token" {
+				t.Errorf("Expected 'token' but got %s", token)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("Expected the token endpoint to be called once but was called %d times", calls)
+		}
+	})
+
+	t.Run("Token is refreshed shortly before it expires", func(t *testing.T) {
+		setup()
+		defer teardown()
+		calls := 0
+		mux.HandleFunc(collectionsTokenURL, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			fmt.Fprintf(w, `{"access_token": "token%d", "token_type": "access_token", "expires_in": 3600}`, calls)
+		})
+
+		ts := client.TokenSource(ProductCollection)
+		ts.SetCredentials("user", "key")
+		if _, err := ts.Token(ctx); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		ts.expiry = time.Now().Add(tokenExpiryDelta / 2)
+
+		token, err := ts.Token(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if token != "token2" {
+			t.Errorf("Expected 'token2' but got %s", token)
+		}
+	})
+
+	t.Run("Token with rejected credentials returns an unauthorized error", func(t *testing.T) {
+		setup()
+		defer teardown()
+		mux.HandleFunc(collectionsTokenURL, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code":"UNAUTHORIZED","message":"Invalid API user credentials"}`)
+		})
+
+		ts := client.TokenSource(ProductCollection)
+		ts.SetCredentials("user", "wrong")
+		_, err := ts.Token(ctx)
+		if !IsUnauthorized(err) {
+			t.Errorf("Expected an unauthorized error but got %v", err)
+		}
+	})
+
+	t.Run("Token without credentials returns an error", func(t *testing.T) {
+		setup()
+		defer teardown()
+		_, err := client.TokenSource(ProductRemittance).Token(ctx)
+		if err == nil {
+			t.Errorf("Expected a non nil error")
+		}
+	})
+}
+
+func TestClient_Do_RetriesUnauthorized(t *testing.T) {
+	setup()
+	defer teardown()
+	tokens := 0
+	mux.HandleFunc(disbursementsTokenURL, func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		fmt.Fprintf(w, `{"access_token": "token%d", "token_type": "access_token", "expires_in": 3600}`, tokens)
+	})
+	mux.HandleFunc(disbursementsBalanceURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"availableBalance": "500", "currency": "EUR"}`)
+	})
+
+	client.TokenSource(ProductDisbursement).SetCredentials("user", "key")
+	balance, err := client.Disbursement.GetBalance(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if balance.AvailableBalance.String() != "500" {
+		t.Errorf("Expected balance of 500 but got %s", balance.AvailableBalance)
+	}
+	if tokens != 2 {
+		t.Errorf("Expected the token to be fetched twice but was fetched %d times", tokens)
+	}
+}
@@ -0,0 +1,161 @@
+package gomomo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// TransactionStatus is the status of a payment or transfer
+type TransactionStatus string
+
+// Transaction statuses returned by the Momo API
+const (
+	StatusPending    TransactionStatus = "PENDING"
+	StatusOngoing    TransactionStatus = "ONGOING"
+	StatusSuccessful TransactionStatus = "SUCCESSFUL"
+	StatusFailed     TransactionStatus = "FAILED"
+	StatusRejected   TransactionStatus = "REJECTED"
+	StatusTimeout    TransactionStatus = "TIMEOUT"
+	StatusCancelled  TransactionStatus = "CANCELLED"
+)
+
+// IsFinal reports whether a transaction in this status will not change any more
+func (s TransactionStatus) IsFinal() bool {
+	switch s {
+	case StatusSuccessful, StatusFailed, StatusRejected, StatusTimeout, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// ErrWaitTimeout is returned when a transaction does not reach a final status within WaitOptions.Timeout
+var ErrWaitTimeout = errors.New("gomomo: timed out waiting for a final transaction status")
+
+// WaitOptions configures how WaitForTransaction and WaitForTransfer poll for a final status.
+// Zero fields take the values of DefaultWaitOptions.
+type WaitOptions struct {
+	// InitialInterval is the delay before the second status lookup
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between lookups
+	MaxInterval time.Duration
+	// Multiplier grows the delay after every lookup
+	Multiplier float64
+	// Jitter randomises each delay by up to this fraction of it, between 0 and 1. NoJitter disables it.
+	Jitter float64
+	// Timeout is how long to wait for a final status, including the time taken by the lookups themselves.
+	// The deadline of the context is always respected.
+	Timeout time.Duration
+}
+
+// NoJitter is the WaitOptions.Jitter that disables jitter, as a zero Jitter takes the default
+const NoJitter = -1
+
+// DefaultWaitOptions are used for any field left unset in WaitOptions
+var DefaultWaitOptions = WaitOptions{
+	InitialInterval: 2 * time.Second,
+	MaxInterval:     30 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.2,
+	Timeout:         5 * time.Minute,
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	opts := DefaultWaitOptions
+	if o == nil {
+		return opts
+	}
+	if o.InitialInterval > 0 {
+		opts.InitialInterval = o.InitialInterval
+	}
+	if o.MaxInterval > 0 {
+		opts.MaxInterval = o.MaxInterval
+	}
+	if o.Multiplier >= 1 {
+		opts.Multiplier = o.Multiplier
+	}
+	switch {
+	case o.Jitter > 0:
+		opts.Jitter = o.Jitter
+	case o.Jitter < 0:
+		opts.Jitter = 0
+	}
+	if o.Timeout > 0 {
+		opts.Timeout = o.Timeout
+	}
+	return opts
+}
+
+// WaitForTransaction polls GetTransaction until the transaction reaches a final status. It returns the
+// last status seen together with ErrWaitTimeout or the context's error when it stops waiting early.
+func (c *CollectionServiceOp) WaitForTransaction(ctx context.Context, transactionID string, opts *WaitOptions) (*PaymentStatusResponse, error) {
+	return waitForStatus(ctx, opts, func(ctx context.Context) (*PaymentStatusResponse, error) {
+		return c.GetTransaction(ctx, transactionID)
+	})
+}
+
+// WaitForTransfer polls GetTransfer until the transfer reaches a final status. It returns the
+// last status seen together with ErrWaitTimeout or the context's error when it stops waiting early.
+func (c *DisbursementServiceOp) WaitForTransfer(ctx context.Context, transferID string, opts *WaitOptions) (*PaymentStatusResponse, error) {
+	return waitForStatus(ctx, opts, func(ctx context.Context) (*PaymentStatusResponse, error) {
+		return c.GetTransfer(ctx, transferID)
+	})
+}
+
+// WaitForTransfer polls GetTransfer until the transfer reaches a final status. It returns the
+// last status seen together with ErrWaitTimeout or the context's error when it stops waiting early.
+func (c *RemittanceServiceOp) WaitForTransfer(ctx context.Context, transferID string, opts *WaitOptions) (*PaymentStatusResponse, error) {
+	return waitForStatus(ctx, opts, func(ctx context.Context) (*PaymentStatusResponse, error) {
+		return c.GetTransfer(ctx, transferID)
+	})
+}
+
+func waitForStatus(ctx context.Context, o *WaitOptions, get func(ctx context.Context) (*PaymentStatusResponse, error)) (*PaymentStatusResponse, error) {
+	opts := o.withDefaults()
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	var last *PaymentStatusResponse
+	for attempt := 0; ; attempt++ {
+		status, err := get(waitCtx)
+		switch {
+		case err == nil:
+			last = status
+			if status.Status.IsFinal() {
+				return status, nil
+			}
+		case waitCtx.Err() != nil:
+			return last, waitError(ctx)
+		case !IsRetryable(err):
+			return last, err
+		}
+
+		if err := sleep(waitCtx, backoff(attempt, opts.InitialInterval, opts.MaxInterval, opts.Multiplier, opts.Jitter)); err != nil {
+			return last, waitError(ctx)
+		}
+	}
+}
+
+// waitError returns the error of a wait that stopped early: the error of ctx when the caller gave up
+// and ErrWaitTimeout when the wait's own timeout expired
+func waitError(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return ErrWaitTimeout
+}
+
+// backoff returns the delay before retry number attempt, starting at initial and growing by multiplier
+// up to maxDelay, randomised by up to jitter of its value in either direction.
+func backoff(attempt int, initial, maxDelay time.Duration, multiplier, jitter float64) time.Duration {
+	delay := float64(initial)
+	for i := 0; i < attempt && delay < float64(maxDelay); i++ {
+		delay *= multiplier
+	}
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+	delay += delay * jitter * (2*rand.Float64() - 1)
+	return time.Duration(delay)
+}
@@ -0,0 +1,136 @@
+package gomomo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var fastWait = &WaitOptions{
+	InitialInterval: time.Millisecond,
+	MaxInterval:     5 * time.Millisecond,
+	Timeout:         time.Second,
+}
+
+func TestCollectionServiceOp_WaitForTransaction(t *testing.T) {
+	setup()
+	defer teardown()
+	transactionID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+
+	calls := 0
+	mux.HandleFunc(fmt.Sprintf("%s/%s", collectionsRequestToPayURL, transactionID), func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			fmt.Fprint(w, `{"status": "PENDING"}`)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"status": "SUCCESSFUL"}`)
+		}
+	})
+
+	status, err := client.Collection.WaitForTransaction(ctx, transactionID, fastWait)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if status.Status != StatusSuccessful {
+		t.Errorf("Expected status %s but got %s", StatusSuccessful, status.Status)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 status lookups but got %d", calls)
+	}
+}
+
+func TestDisbursementServiceOp_WaitForTransfer(t *testing.T) {
+	transferID := "6c6eb16c-8b34-4d5d-bd41-2a9303f65075"
+
+	t.Run("WaitForTransfer stops at the timeout", func(t *testing.T) {
+		setup()
+		defer teardown()
+		mux.HandleFunc(fmt.Sprintf("%s/%s", disbursementsTransferURL, transferID), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"status": "PENDING"}`)
+		})
+
+		status, err := client.Disbursement.WaitForTransfer(ctx, transferID, &WaitOptions{
+			InitialInterval: time.Millisecond,
+			Timeout:         20 * time.Millisecond,
+		})
+		if err != ErrWaitTimeout {
+			t.Errorf("Expected ErrWaitTimeout but got %v", err)
+		}
+		if status == nil || status.Status != StatusPending {
+			t.Errorf("Expected the last status to be returned but got %#v", status)
+		}
+	})
+
+	t.Run("WaitForTransfer bounds a slow lookup by the timeout", func(t *testing.T) {
+		setup()
+		defer teardown()
+		mux.HandleFunc(fmt.Sprintf("%s/%s", disbursementsTransferURL, transferID), func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(300 * time.Millisecond)
+			fmt.Fprint(w, `{"status": "SUCCESSFUL"}`)
+		})
+
+		start := time.Now()
+		_, err := client.Disbursement.WaitForTransfer(ctx, transferID, &WaitOptions{Timeout: 20 * time.Millisecond})
+		if err != ErrWaitTimeout {
+			t.Errorf("Expected ErrWaitTimeout but got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+			t.Errorf("Expected the wait to stop at the timeout but it took %s", elapsed)
+		}
+	})
+
+	t.Run("WaitForTransfer respects the context deadline", func(t *testing.T) {
+		setup()
+		defer teardown()
+		mux.HandleFunc(fmt.Sprintf("%s/%s", disbursementsTransferURL, transferID), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"status": "ONGOING"}`)
+		})
+
+		deadline, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := client.Disbursement.WaitForTransfer(deadline, transferID, fastWait)
+		if err != context.DeadlineExceeded {
+			t.Errorf("Expected context.DeadlineExceeded but got %v", err)
+		}
+	})
+
+	t.Run("WaitForTransfer returns errors that are not retryable", func(t *testing.T) {
+		setup()
+		defer teardown()
+		mux.HandleFunc(fmt.Sprintf("%s/%s", disbursementsTransferURL, transferID), func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := client.Disbursement.WaitForTransfer(ctx, transferID, fastWait)
+		if !IsNotFound(err) {
+			t.Errorf("Expected a not found error but got %v", err)
+		}
+	})
+}
+
+func TestWaitOptions_NoJitter(t *testing.T) {
+	if opts := (&WaitOptions{Jitter: NoJitter}).withDefaults(); opts.Jitter != 0 {
+		t.Errorf("Expected NoJitter to disable jitter but got %v", opts.Jitter)
+	}
+	if opts := (&WaitOptions{}).withDefaults(); opts.Jitter != DefaultWaitOptions.Jitter {
+		t.Errorf("Expected a zero Jitter to take the default but got %v", opts.Jitter)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if delay := backoff(attempt, time.Second, 5*time.Second, 2, 0); delay != expected {
+			t.Errorf("backoff(%d) = %s, expected %s", attempt, delay, expected)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if delay := backoff(0, time.Second, 5*time.Second, 2, 0.5); delay < 500*time.Millisecond || delay > 1500*time.Millisecond {
+			t.Errorf("Expected a jittered delay within 50%% of 1s but got %s", delay)
+		}
+	}
+}